@@ -0,0 +1,70 @@
+package gosolar
+
+import "math"
+
+// SeaLevelPressure is the standard atmospheric pressure at sea level, in hPa, which air mass is relative to
+const SeaLevelPressure = 1013.25
+
+// AirMassModel selects the formula used to approximate the relative optical air mass
+type AirMassModel int
+
+const (
+	// AirMassKastenYoung is Kasten and Young's (1989) formula
+	AirMassKastenYoung AirMassModel = iota
+	// AirMassKasten is Kasten's (1966) formula
+	AirMassKasten
+	// AirMassGueymard is Gueymard's (1993) formula
+	AirMassGueymard
+	// AirMassSimple is the secant of the zenith angle, which assumes a flat atmosphere
+	AirMassSimple
+)
+
+// RelativeAirMass returns the optical air mass relative to the one at the zenith, for the given zenith angle in
+// degrees. It is NaN when the sun is below the horizon.
+func RelativeAirMass(zenith float64, model AirMassModel) float64 {
+	if zenith > 90 {
+		return math.NaN()
+	}
+	cosZenith := math.Cos(radians(zenith))
+
+	switch model {
+	case AirMassKasten:
+		return 1 / (cosZenith + 0.15*math.Pow(93.885-zenith, -1.253))
+	case AirMassGueymard:
+		return 1 / (cosZenith + 0.00176759*zenith*math.Pow(94.37515-zenith, -1.21563))
+	case AirMassSimple:
+		return 1 / cosZenith
+	default:
+		return 1 / (cosZenith + 0.50572*math.Pow(96.07995-zenith, -1.6364))
+	}
+}
+
+// AbsoluteAirMass returns the air mass corrected for the site pressure, in hPa, from the relative air mass
+func AbsoluteAirMass(relative, pressure float64) float64 {
+	return relative * pressure / SeaLevelPressure
+}
+
+// PressureFromElevation returns the standard atmospheric pressure, in hPa, at the given elevation in meters
+func PressureFromElevation(elevation float64) float64 {
+	return math.Pow((44331.514-elevation)/11880.516, 1/0.1902632)
+}
+
+// RelativeAirMass returns the relative optical air mass for the current SolarZenithAngle. It is NaN when the sun is
+// below the horizon.
+func (sc *SolarCalculation) RelativeAirMass(model AirMassModel) float64 {
+	return RelativeAirMass(sc.SolarZenithAngle(), model)
+}
+
+// AbsoluteAirMass returns the optical air mass for the current SolarZenithAngle, corrected for the site pressure
+func (sc *SolarCalculation) AbsoluteAirMass(model AirMassModel) float64 {
+	return AbsoluteAirMass(sc.RelativeAirMass(model), sc.sitePressure())
+}
+
+// sitePressure returns the pressure set with WithAtmosphere or SetAtmosphere, in hPa, or the standard pressure at the
+// site elevation when none was set
+func (sc *SolarCalculation) sitePressure() float64 {
+	if sc.pressureSet {
+		return sc.pressure
+	}
+	return PressureFromElevation(sc.elevation)
+}
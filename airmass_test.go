@@ -0,0 +1,51 @@
+package gosolar
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeAirMass(t *testing.T) {
+	for _, model := range []AirMassModel{AirMassKastenYoung, AirMassKasten, AirMassGueymard, AirMassSimple} {
+		assert.InDelta(t, 1, RelativeAirMass(0, model), 1e-3)
+		assert.InDelta(t, 2, RelativeAirMass(60, model), 1e-2)
+		assert.True(t, math.IsNaN(RelativeAirMass(91, model)))
+	}
+
+	// Near the horizon the curvature of the atmosphere limits the air mass
+	assert.InDelta(t, 37.92, RelativeAirMass(90, AirMassKastenYoung), 0.01)
+	assert.InDelta(t, 36.51, RelativeAirMass(90, AirMassKasten), 0.01)
+	assert.InDelta(t, 37.81, RelativeAirMass(90, AirMassGueymard), 0.01)
+	assert.Greater(t, RelativeAirMass(89.9, AirMassSimple), 500.0)
+}
+
+func TestAbsoluteAirMass(t *testing.T) {
+	assert.InDelta(t, SeaLevelPressure, PressureFromElevation(0), 1e-4)
+	assert.InDelta(t, 898.75, PressureFromElevation(1000), 0.01)
+	assert.Equal(t, 1.0, AbsoluteAirMass(2, SeaLevelPressure/2))
+
+	assert.Equal(t, RelativeAirMass(sc.SolarZenithAngle(), AirMassGueymard), sc.RelativeAirMass(AirMassGueymard))
+	assert.InDelta(t, sc.RelativeAirMass(AirMassKastenYoung), sc.AbsoluteAirMass(AirMassKastenYoung), 1e-4)
+
+	high, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01", WithElevation(1000))
+	require.NoError(t, err)
+	assert.InDelta(t, sc.RelativeAirMass(AirMassKastenYoung)*898.75/SeaLevelPressure, high.AbsoluteAirMass(AirMassKastenYoung), 1e-4)
+}
+
+func TestAbsoluteAirMassUsesSetPressure(t *testing.T) {
+	// A low-pressure system at a site 1000 m high: the measured pressure wins over the one from the elevation
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01",
+		WithElevation(1000), WithAtmosphere(870, 10))
+	require.NoError(t, err)
+	relative := calc.RelativeAirMass(AirMassKastenYoung)
+	assert.InDelta(t, relative*870/SeaLevelPressure, calc.AbsoluteAirMass(AirMassKastenYoung), 1e-12)
+
+	solis := SimplifiedSolis(calc.RefractionCorrectedElevation(RefractionNOAA), 0.1, 1, 870, calc.ExtraterrestrialIrradiance())
+	assert.Equal(t, solis, calc.ClearSkySimplifiedSolis(0.1, 1))
+
+	require.NoError(t, calc.SetAtmosphere(920, 10))
+	assert.InDelta(t, relative*920/SeaLevelPressure, calc.AbsoluteAirMass(AirMassKastenYoung), 1e-12)
+}
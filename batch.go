@@ -0,0 +1,135 @@
+package gosolar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNilBatchOutput is returned when a batch is evaluated without an output
+	ErrNilBatchOutput = errors.New("batch output must not be nil")
+	// ErrBatchOutputSize is returned when the output slices of a batch do not hold one value per site and timestamp
+	ErrBatchOutputSize = errors.New("batch output must hold len(sites)*len(times) values")
+)
+
+// Site is a location evaluated by EvaluateBatch, e.g. a rooftop with a tilted surface. The timestamps of a batch are
+// absolute instants, so a site needs no time zone.
+type Site struct {
+	Latitude       float64 // degrees
+	Longitude      float64 // degrees
+	Elevation      float64 // meters above sea level, which sets the pressure for the apparent zenith angle
+	SurfaceTilt    float64 // tilt of the surface from horizontal, in degrees
+	SurfaceAzimuth float64 // azimuth of the surface, in degrees clockwise from north
+}
+
+// BatchOutput holds the results of EvaluateBatch. The value for site i at timestamp j is at index
+// i*len(times)+j of every slice. Angles are in degrees.
+type BatchOutput struct {
+	Zenith         []float64 // geometric zenith angle
+	ApparentZenith []float64 // zenith angle corrected for the refraction at the site pressure, with RefractionNOAA
+	Azimuth        []float64
+	Incidence      []float64 // incidence angle on the surface of the site
+}
+
+// NewBatchOutput allocates a BatchOutput for the given number of sites and timestamps
+func NewBatchOutput(sites, times int) *BatchOutput {
+	return &BatchOutput{
+		Zenith:         make([]float64, sites*times),
+		ApparentZenith: make([]float64, sites*times),
+		Azimuth:        make([]float64, sites*times),
+		Incidence:      make([]float64, sites*times),
+	}
+}
+
+// EvaluateBatch computes the position of the sun for every site at every timestamp and fills out, which must be
+// preallocated, e.g. with NewBatchOutput. Sites are evaluated in parallel by the given number of workers, or by
+// GOMAXPROCS workers when workers is not positive. The options are applied to the calculation of every site, as
+// in CalculatorFromTime, after the elevation of the site, so WithAtmosphere sets the pressure of every site.
+//
+// Evaluation stops as soon as ctx is done, returning its error, or a site is invalid, returning the validation
+// error of the first invalid site found. The output is then only partially filled.
+func EvaluateBatch(ctx context.Context, sites []Site, times []time.Time, out *BatchOutput, workers int, opts ...Option) error {
+	if out == nil {
+		return ErrNilBatchOutput
+	}
+	n := len(sites) * len(times)
+	if len(out.Zenith) != n || len(out.ApparentZenith) != n || len(out.Azimuth) != n || len(out.Incidence) != n {
+		return ErrBatchOutputSize
+	}
+	if n == 0 {
+		return ctx.Err()
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		jobs     = make(chan int)
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := evaluateSite(ctx, i, sites[i], times, out, opts); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range sites {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// evaluateSite fills the output of the site at index i for every timestamp
+func evaluateSite(ctx context.Context, i int, site Site, times []time.Time, out *BatchOutput, opts []Option) error {
+	siteOpts := append([]Option{WithElevation(site.Elevation)}, opts...)
+	sc, err := CalculatorFromTime(site.Latitude, site.Longitude, times[0], siteOpts...)
+	if err != nil {
+		return fmt.Errorf("site %d: %w", i, err)
+	}
+
+	for j, t := range times {
+		// The context is checked every 1024 timestamps to keep its cost negligible
+		if j%1024 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		position := sc.PositionAt(t)
+		k := i*len(times) + j
+
+		out.Zenith[k] = position.Zenith
+		out.ApparentZenith[k] = position.Zenith - Refraction(position.Elevation, sc.sitePressure(), sc.temperature, RefractionNOAA)
+		out.Azimuth[k] = position.Azimuth
+		out.Incidence[k] = incidenceAngle(site.Latitude, position.Declination, position.HourAngle, site.SurfaceTilt, site.SurfaceAzimuth)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package gosolar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchInput() ([]Site, []time.Time) {
+	sites := []Site{
+		{Latitude: 23.0975036, Longitude: -82.4206579, Elevation: 59, SurfaceTilt: 30, SurfaceAzimuth: 180},
+		{Latitude: 40.4168, Longitude: -3.7038, SurfaceTilt: 45, SurfaceAzimuth: 135},
+		{Latitude: -33.8688, Longitude: 151.2093, SurfaceAzimuth: 0},
+	}
+
+	start := time.Date(2023, time.June, 21, 0, 0, 0, 0, time.UTC)
+	times := make([]time.Time, 48)
+	for i := range times {
+		times[i] = start.Add(time.Duration(i) * 30 * time.Minute)
+	}
+	return sites, times
+}
+
+func TestEvaluateBatch(t *testing.T) {
+	sites, times := batchInput()
+	out := NewBatchOutput(len(sites), len(times))
+
+	require.NoError(t, EvaluateBatch(context.Background(), sites, times, out, 2))
+
+	for i, site := range sites {
+		calc, err := CalculatorFromTime(site.Latitude, site.Longitude, times[0], WithElevation(site.Elevation))
+		require.NoError(t, err)
+
+		for j, instant := range times {
+			require.NoError(t, calc.SetTime(instant))
+			k := i*len(times) + j
+
+			assert.InDelta(t, calc.SolarZenithAngle(), out.Zenith[k], 1e-6)
+			assert.InDelta(t, calc.SolarZenithAngle()-Refraction(90-calc.SolarZenithAngle(), PressureFromElevation(site.Elevation),
+				calc.temperature, RefractionNOAA), out.ApparentZenith[k], 1e-6)
+			assert.InDelta(t, calc.SolarAzimuthAngle(), out.Azimuth[k], 1e-6)
+			assert.InDelta(t, calc.IncidenceOnTiltedSurface(site.SurfaceTilt, site.SurfaceAzimuth), out.Incidence[k], 1e-6)
+		}
+	}
+}
+
+func TestEvaluateBatchElevation(t *testing.T) {
+	_, times := batchInput()
+	sites := []Site{
+		{Latitude: 40.4168, Longitude: -3.7038},
+		{Latitude: 40.4168, Longitude: -3.7038, Elevation: 3000},
+	}
+	out := NewBatchOutput(len(sites), len(times))
+	require.NoError(t, EvaluateBatch(context.Background(), sites, times, out, 1))
+
+	// The elevation leaves the geometry unchanged but thins the air, which bends the rays less
+	refracted := 0
+	for j := range times {
+		assert.Equal(t, out.Zenith[j], out.Zenith[len(times)+j])
+		assert.LessOrEqual(t, out.ApparentZenith[j], out.ApparentZenith[len(times)+j])
+		if out.ApparentZenith[j] < out.ApparentZenith[len(times)+j] {
+			refracted++
+		}
+	}
+	assert.Positive(t, refracted)
+
+	// The atmosphere given in the options overrides the pressure of every site
+	atmosphere := NewBatchOutput(len(sites), len(times))
+	require.NoError(t, EvaluateBatch(context.Background(), sites, times, atmosphere, 1, WithAtmosphere(800, 10)))
+	assert.Equal(t, atmosphere.ApparentZenith[:len(times)], atmosphere.ApparentZenith[len(times):])
+}
+
+func TestEvaluateBatchWorkers(t *testing.T) {
+	sites, times := batchInput()
+	sequential := NewBatchOutput(len(sites), len(times))
+	parallel := NewBatchOutput(len(sites), len(times))
+
+	require.NoError(t, EvaluateBatch(context.Background(), sites, times, sequential, 1, WithEngine(SPA{DeltaT: 69})))
+	require.NoError(t, EvaluateBatch(context.Background(), sites, times, parallel, 0, WithEngine(SPA{DeltaT: 69})))
+
+	assert.Equal(t, sequential, parallel)
+}
+
+func TestEvaluateBatchErrors(t *testing.T) {
+	sites, times := batchInput()
+
+	err := EvaluateBatch(context.Background(), sites, times, NewBatchOutput(len(sites), len(times)-1), 2)
+	assert.ErrorIs(t, err, ErrBatchOutputSize)
+
+	err = EvaluateBatch(context.Background(), sites, times, nil, 2)
+	assert.ErrorIs(t, err, ErrNilBatchOutput)
+
+	invalidSites := append([]Site{}, sites...)
+	invalidSites[1].Latitude = 91
+	err = EvaluateBatch(context.Background(), invalidSites, times, NewBatchOutput(len(sites), len(times)), 2)
+	assert.ErrorIs(t, err, ErrLatitudeRange)
+	assert.ErrorContains(t, err, "site 1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = EvaluateBatch(ctx, sites, times, NewBatchOutput(len(sites), len(times)), 2)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func BenchmarkEvaluateBatch(b *testing.B) {
+	sites, times := batchInput()
+	sites = append(append(sites, sites...), sites...)
+	out := NewBatchOutput(len(sites), len(times))
+
+	for i := 0; i < b.N; i++ {
+		_ = EvaluateBatch(context.Background(), sites, times, out, 0)
+	}
+}
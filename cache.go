@@ -0,0 +1,52 @@
+package gosolar
+
+import "time"
+
+// cache holds the quantities that only depend on the calculation's inputs, so reading them never parses the date or
+// runs the Engine again and never mutates the calculation. The date-dependent part is computed by refreshTime, once
+// per date, time of the day, timezone offset and Engine, and the site-dependent part by refreshSite, which is cheap
+// enough to run on every change of the latitude or longitude.
+type cache struct {
+	// Date-dependent part
+	julianDay float64
+	sun       SunCoordinates
+
+	// Site-dependent part
+	hourAngle float64
+	zenith    float64
+	azimuth   float64
+}
+
+// refreshTime recomputes the whole cache. It must be called after any change to the date, time of the day, timezone
+// offset or Engine.
+func (sc *SolarCalculation) refreshTime() {
+	sc.cache.julianDay = sc.julianDay()
+	sc.cache.sun = sc.engine().Sun(sc.cache.julianDay)
+	sc.refreshSite()
+}
+
+// refreshSite recomputes the site-dependent part of the cache from the cached position of the sun. It must be called
+// after any change to the latitude or longitude.
+func (sc *SolarCalculation) refreshSite() {
+	sc.cache.hourAngle = (sc.TrueSolarTime() / 4) - 180
+
+	declination := sc.cache.sun.Declination
+	sc.cache.zenith = zenithAngle(sc.latitude, declination, sc.cache.hourAngle)
+	sc.cache.azimuth = azimuthAngle(sc.latitude, declination, sc.cache.hourAngle, sc.cache.zenith)
+}
+
+// julianDay computes the Julian Day of the calculation's date, time of the day and timezone offset. The date is
+// counted in whole seconds from the Unix epoch, which unlike a time.Duration does not overflow for distant years.
+func (sc *SolarCalculation) julianDay() float64 {
+	parsedDate, err := time.Parse("2006-01-02", sc.date)
+	if err != nil {
+		return 0
+	}
+
+	julianDay := julianDayOf(parsedDate) + (sc.dayTime - float64(sc.timeZoneOffset)/24)
+	if sc.logger != nil {
+		sc.logger.Debug("julian day", "date", sc.date, "dayTime", sc.dayTime, "value", julianDay)
+	}
+
+	return julianDay
+}
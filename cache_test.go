@@ -0,0 +1,140 @@
+package gosolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func BenchmarkSolarAzimuthAngle(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sc.SolarAzimuthAngle()
+	}
+}
+
+func BenchmarkPosition(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sc.SolarZenithAngle()
+		sc.SolarAzimuthAngle()
+		sc.SolarDeclination()
+		sc.EquationOfTime()
+		sc.IncidenceOnTiltedSurface(30, 180)
+	}
+}
+
+// BenchmarkPositionUncached is the baseline of BenchmarkPosition: changing the time of day every iteration forces
+// the cache to run the engine again
+func BenchmarkPositionUncached(b *testing.B) {
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01")
+	require.NoError(b, err)
+
+	for i := 0; i < b.N; i++ {
+		_ = calc.SetDayTime(0.5 + float64(i%2)/1440)
+		calc.SolarZenithAngle()
+		calc.SolarAzimuthAngle()
+		calc.SolarDeclination()
+		calc.EquationOfTime()
+		calc.IncidenceOnTiltedSurface(30, 180)
+	}
+}
+
+func BenchmarkDaySimulation(b *testing.B) {
+	calc, err := Calculator(23.0975036, -82.4206579, 0, "America/New_York", "2023-01-01")
+	require.NoError(b, err)
+
+	for i := 0; i < b.N; i++ {
+		_ = calc.SetDayTime(float64(i%1440) / 1440)
+		calc.SolarZenithAngle()
+		calc.SolarAzimuthAngle()
+		calc.IncidenceOnTiltedSurface(30, 180)
+	}
+}
+
+func TestCachedPositionDoesNotAllocate(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		sc.SolarZenithAngle()
+		sc.SolarAzimuthAngle()
+		sc.SolarDeclination()
+		sc.EquationOfTime()
+		sc.IncidenceOnTiltedSurface(30, 180)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestSettersRefreshCache(t *testing.T) {
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01")
+	require.NoError(t, err)
+
+	require.NoError(t, calc.SetLatitude(40.7128))
+	require.NoError(t, calc.SetLongitude(-74.006))
+	require.NoError(t, calc.SetDate("2023-06-21"))
+	require.NoError(t, calc.SetDayTime(0.75))
+	calc.SetEngine(SPA{DeltaT: 69})
+
+	fresh, err := Calculator(40.7128, -74.006, 0.75, "America/New_York", "2023-06-21", WithEngine(SPA{DeltaT: 69}))
+	require.NoError(t, err)
+
+	assert.Equal(t, fresh.JulianDay(), calc.JulianDay())
+	assert.Equal(t, fresh.SolarDeclination(), calc.SolarDeclination())
+	assert.Equal(t, fresh.EquationOfTime(), calc.EquationOfTime())
+	assert.Equal(t, fresh.SunHourAngle(), calc.SunHourAngle())
+	assert.Equal(t, fresh.SolarZenithAngle(), calc.SolarZenithAngle())
+	assert.Equal(t, fresh.SolarAzimuthAngle(), calc.SolarAzimuthAngle())
+}
+
+func TestSetTimeRefreshesCache(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	instant := time.Date(2023, time.March, 12, 9, 30, 0, 0, loc)
+
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01")
+	require.NoError(t, err)
+	require.NoError(t, calc.SetTime(instant))
+
+	fresh, err := CalculatorFromTime(23.0975036, -82.4206579, instant)
+	require.NoError(t, err)
+
+	assert.Equal(t, fresh.JulianDay(), calc.JulianDay())
+	assert.Equal(t, fresh.SolarZenithAngle(), calc.SolarZenithAngle())
+	assert.Equal(t, fresh.SolarAzimuthAngle(), calc.SolarAzimuthAngle())
+}
+
+// countingEngine counts the positions of the sun computed by the calculation
+type countingEngine struct {
+	calls int
+}
+
+func (e *countingEngine) Sun(jd float64) SunCoordinates {
+	e.calls++
+	return NOAA{}.Sun(jd)
+}
+
+func TestCacheRunsEngineOncePerTimeChange(t *testing.T) {
+	engine := &countingEngine{}
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01", WithEngine(engine))
+	require.NoError(t, err)
+	assert.Equal(t, 1, engine.calls)
+
+	require.NoError(t, calc.SetDate("2023-06-21"))
+	assert.Equal(t, 2, engine.calls)
+	require.NoError(t, calc.SetDayTime(0.75))
+	assert.Equal(t, 3, engine.calls)
+
+	// Moving the site reuses the position of the sun
+	require.NoError(t, calc.SetLatitude(40.7128))
+	require.NoError(t, calc.SetLongitude(-74.006))
+	for i := 0; i < 10; i++ {
+		calc.Position()
+		calc.JulianDay()
+		calc.SolarZenithAngle()
+		calc.SolarAzimuthAngle()
+		calc.IncidenceOnTiltedSurface(30, 180)
+	}
+	assert.Equal(t, 3, engine.calls)
+
+	fresh, err := Calculator(40.7128, -74.006, 0.75, "America/New_York", "2023-06-21")
+	require.NoError(t, err)
+	assert.Equal(t, fresh.Position(), calc.Position())
+}
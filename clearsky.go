@@ -0,0 +1,117 @@
+package gosolar
+
+import "math"
+
+// Irradiance holds the components of the solar irradiance at the ground, in W/m²
+type Irradiance struct {
+	GHI float64 // global horizontal irradiance
+	DNI float64 // direct normal irradiance
+	DHI float64 // diffuse horizontal irradiance
+}
+
+// Haurwitz returns the clear-sky global horizontal irradiance, in W/m², for the given zenith angle in degrees,
+// using the Haurwitz (1945) model. It is 0 when the sun is below the horizon.
+func Haurwitz(zenith float64) float64 {
+	cosZenith := math.Cos(radians(zenith))
+	if cosZenith <= 0 {
+		return 0
+	}
+	return 1098 * cosZenith * math.Exp(-0.059/cosZenith)
+}
+
+// Ineichen returns the clear-sky irradiance using the Ineichen and Perez (2002) model, for the given zenith angle in
+// degrees, absolute air mass, Linke turbidity, site elevation in meters and extraterrestrial normal irradiance in
+// W/m². It is zero when the sun is below the horizon.
+func Ineichen(zenith, airMassAbsolute, linkeTurbidity, elevation, dniExtra float64) Irradiance {
+	cosZenith := math.Cos(radians(zenith))
+	if cosZenith <= 0 || math.IsNaN(airMassAbsolute) {
+		return Irradiance{}
+	}
+
+	fh1 := math.Exp(-elevation / 8000)
+	fh2 := math.Exp(-elevation / 1250)
+	cg1 := 5.09e-05*elevation + 0.868
+	cg2 := 3.92e-05*elevation + 0.0387
+
+	ghi := cg1 * dniExtra * cosZenith * math.Exp(-cg2*airMassAbsolute*(fh1+fh2*(linkeTurbidity-1)))
+
+	// Beam irradiance, limited by the empirical correction of the GHI
+	b := 0.664 + 0.163/fh1
+	bnci := dniExtra * math.Max(b*math.Exp(-0.09*airMassAbsolute*(linkeTurbidity-1)), 0)
+	bnci2 := ghi * math.Max((1-(0.1-0.2*math.Exp(-linkeTurbidity))/(0.1+0.882/fh1))/cosZenith, 0)
+	dni := math.Min(bnci, bnci2)
+
+	return Irradiance{GHI: ghi, DNI: dni, DHI: ghi - dni*cosZenith}
+}
+
+// SimplifiedSolis returns the clear-sky irradiance using the simplified Solis model (Ineichen, 2008), for the given
+// apparent sun elevation in degrees, aerosol optical depth at 700 nm, precipitable water in cm, pressure in hPa and
+// extraterrestrial normal irradiance in W/m². Precipitable water below 0.2 cm is raised to 0.2, the lower limit of
+// the model.
+func SimplifiedSolis(elevation, aod700, precipitableWater, pressure, dniExtra float64) Irradiance {
+	w := math.Max(precipitableWater, 0.2)
+	logW := math.Log(w)
+	logP := math.Log(pressure / SeaLevelPressure)
+	aod2 := aod700 * aod700
+
+	// Enhanced extraterrestrial irradiance
+	i0p := dniExtra * (0.12*math.Pow(w, 0.56)*aod2 + 0.97*math.Pow(w, 0.032)*aod700 + 1.08*math.Pow(w, 0.0051) + 0.071*logP)
+
+	// Beam, global and diffuse optical depths and their exponents
+	taub := (1.82+0.056*logW+0.0071*logW*logW)*aod700 + 0.33 + 0.045*logW + 0.0096*logW*logW + (0.0089*w+0.13)*logP
+	b := (0.00925*aod2+0.0148*aod700-0.0172)*logW - 0.7565*aod2 + 0.5057*aod700 + 0.4557
+
+	taug := (1.24+0.047*logW+0.0061*logW*logW)*aod700 + 0.27 + 0.043*logW + 0.0090*logW*logW + (0.0079*w+0.1)*logP
+	g := -0.0147*logW - 0.3079*aod2 + 0.2846*aod700 + 0.3798
+
+	taud := solisDiffuseDepth(w, aod700, logP)
+	d := -0.337*aod2 + 0.63*aod700 + 0.116 + logP/(18+152*aod700)
+
+	// Avoids dividing by zero when the sun is at or below the horizon
+	sinElevation := math.Max(1e-30, math.Sin(radians(elevation)))
+
+	return Irradiance{
+		GHI: i0p * math.Exp(-taug/math.Pow(sinElevation, g)) * sinElevation,
+		DNI: i0p * math.Exp(-taub/math.Pow(sinElevation, b)),
+		DHI: i0p * math.Exp(-taud/math.Pow(sinElevation, d)),
+	}
+}
+
+// ClearSkyHaurwitz returns the clear-sky global horizontal irradiance, in W/m², for the current SolarZenithAngle.
+// The Haurwitz model does not split the irradiance into its direct and diffuse components.
+func (sc *SolarCalculation) ClearSkyHaurwitz() float64 {
+	return Haurwitz(sc.SolarZenithAngle())
+}
+
+// ClearSkyIneichen returns the clear-sky irradiance for the current SolarZenithAngle and the site elevation, using
+// the Ineichen and Perez model with the given Linke turbidity, e.g. 2 for a very clean sky and 5 for a hazy one
+func (sc *SolarCalculation) ClearSkyIneichen(linkeTurbidity float64) Irradiance {
+	airMass := sc.AbsoluteAirMass(AirMassKastenYoung)
+	return Ineichen(sc.SolarZenithAngle(), airMass, linkeTurbidity, sc.elevation, sc.ExtraterrestrialIrradiance())
+}
+
+// ClearSkySimplifiedSolis returns the clear-sky irradiance for the current apparent sun elevation, corrected for
+// refraction with RefractionNOAA, and the site pressure, using the simplified Solis model with the given aerosol
+// optical depth at 700 nm, typically 0.1, and precipitable water in cm, typically 1
+func (sc *SolarCalculation) ClearSkySimplifiedSolis(aod700, precipitableWater float64) Irradiance {
+	elevation := sc.RefractionCorrectedElevation(RefractionNOAA)
+	return SimplifiedSolis(elevation, aod700, precipitableWater, sc.sitePressure(), sc.ExtraterrestrialIrradiance())
+}
+
+// solisDiffuseDepth returns the diffuse optical depth of the simplified Solis model, whose coefficients change at
+// an aerosol optical depth of 0.05
+func solisDiffuseDepth(w, aod700, logP float64) float64 {
+	var td0, td1, td2, td3, td4, tdp float64
+
+	if aod700 < 0.05 {
+		td4, td3, td2 = 86*w-13800, -3.11*w+79.4, -0.23*w+74.8
+		td1, td0 = 0.092*w-8.86, 0.0042*w+3.12
+		tdp = -0.83 * math.Pow(1+aod700, -17.2)
+	} else {
+		td4, td3, td2 = -0.21*w+11.6, 0.27*w-20.7, -0.134*w+15.5
+		td1, td0 = 0.0554*w-5.71, 0.0057*w+2.94
+		tdp = -0.71 * math.Pow(1+aod700, -15.0)
+	}
+
+	return td4*math.Pow(aod700, 4) + td3*math.Pow(aod700, 3) + td2*aod700*aod700 + td1*aod700 + td0 + tdp*logP
+}
@@ -0,0 +1,71 @@
+package gosolar
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHaurwitz(t *testing.T) {
+	assert.InDelta(t, 1035.0920, Haurwitz(0), 1e-4)
+	assert.InDelta(t, 714.2503, Haurwitz(45), 1e-4)
+	assert.Equal(t, 0.0, Haurwitz(90))
+	assert.Equal(t, 0.0, Haurwitz(120))
+
+	assert.Equal(t, Haurwitz(sc.SolarZenithAngle()), sc.ClearSkyHaurwitz())
+}
+
+func TestIneichen(t *testing.T) {
+	// Reference values from pvlib's test suite
+	irradiance := Ineichen(10, 1, 3, 0, 1364)
+	assert.InDelta(t, 1038.159219, irradiance.GHI, 1e-6)
+	assert.InDelta(t, 942.208186, irradiance.DNI, 1e-6)
+	assert.InDelta(t, 110.265293, irradiance.DHI, 1e-6)
+
+	assert.Equal(t, Irradiance{}, Ineichen(95, math.NaN(), 3, 0, 1364))
+}
+
+func TestSimplifiedSolis(t *testing.T) {
+	// Reference values from pvlib's test suite
+	irradiance := SimplifiedSolis(80, 0.1, 1, 1013.25, 1364)
+	assert.InDelta(t, 1064.653145, irradiance.GHI, 1e-6)
+	assert.InDelta(t, 959.335463, irradiance.DNI, 1e-6)
+	assert.InDelta(t, 129.125602, irradiance.DHI, 1e-6)
+
+	night := SimplifiedSolis(-10, 0.1, 1, 1013.25, 1364)
+	assert.InDelta(t, 0, night.GHI, 1e-9)
+	assert.InDelta(t, 0, night.DNI, 1e-9)
+	assert.InDelta(t, 0, night.DHI, 1e-9)
+}
+
+func TestClearSkyMethods(t *testing.T) {
+	ineichen := sc.ClearSkyIneichen(3)
+	assert.InDelta(t, 706.32, ineichen.GHI, 0.01)
+	assert.InDelta(t, 895.29, ineichen.DNI, 0.01)
+
+	solis := sc.ClearSkySimplifiedSolis(0.1, 1)
+	assert.InDelta(t, 717.66, solis.GHI, 0.01)
+	assert.InDelta(t, 893.63, solis.DNI, 0.01)
+
+	// Less air above the site lets more beam irradiance through
+	high, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01", WithElevation(3000))
+	require.NoError(t, err)
+	assert.Greater(t, high.ClearSkyIneichen(3).DNI, ineichen.DNI)
+	assert.Greater(t, high.ClearSkySimplifiedSolis(0.1, 1).DNI, solis.DNI)
+}
+
+func TestClearSkySimplifiedSolisAtLowSun(t *testing.T) {
+	// Half an hour after sunrise the refraction lifts the sun by about a fifth of a degree
+	calc, err := Calculator(23.0975036, -82.4206579, 7.7/24, "America/New_York", "2023-01-01")
+	require.NoError(t, err)
+	apparent := calc.RefractionCorrectedElevation(RefractionNOAA)
+	require.Less(t, apparent, 10.0)
+
+	solis := calc.ClearSkySimplifiedSolis(0.1, 1)
+	assert.Equal(t, SimplifiedSolis(apparent, 0.1, 1, PressureFromElevation(0), calc.ExtraterrestrialIrradiance()), solis)
+
+	geometric := SimplifiedSolis(calc.SolarIncidenceAngle(), 0.1, 1, PressureFromElevation(0), calc.ExtraterrestrialIrradiance())
+	assert.Greater(t, solis.DNI, geometric.DNI+1)
+}
@@ -0,0 +1,84 @@
+package gosolar
+
+import "math"
+
+// RightAscension returns the apparent right ascension of the sun, in [0, 360) degrees
+func (sc *SolarCalculation) RightAscension() float64 {
+	return sc.sun().RightAscension
+}
+
+// GreenwichMeanSiderealTime returns the mean sidereal time at Greenwich, in [0, 360) degrees
+func (sc *SolarCalculation) GreenwichMeanSiderealTime() float64 {
+	return greenwichMeanSiderealTime(sc.JulianDay())
+}
+
+// GreenwichApparentSiderealTime returns the apparent sidereal time at Greenwich, i.e. the mean sidereal time
+// corrected for the nutation in longitude, in [0, 360) degrees
+func (sc *SolarCalculation) GreenwichApparentSiderealTime() float64 {
+	sun := sc.sun()
+	return greenwichApparentSiderealTime(sc.JulianDay(), sun.Nutation, sun.Obliquity)
+}
+
+// LocalSiderealTime returns the apparent sidereal time at the calculation's longitude, in [0, 360) degrees.
+// The local hour angle of the sun is the local sidereal time minus its right ascension.
+func (sc *SolarCalculation) LocalSiderealTime() float64 {
+	return normalizeDegrees(sc.GreenwichApparentSiderealTime() + sc.longitude)
+}
+
+// EclipticToEquatorial converts ecliptic longitude and latitude to right ascension, in [0, 360), and declination
+// for the given obliquity of the ecliptic. All angles are in degrees.
+func EclipticToEquatorial(longitude, latitude, obliquity float64) (rightAscension, declination float64) {
+	lon, lat, eps := radians(longitude), radians(latitude), radians(obliquity)
+
+	rightAscension = math.Atan2(math.Sin(lon)*math.Cos(eps)-math.Tan(lat)*math.Sin(eps), math.Cos(lon))
+	declination = math.Asin(math.Sin(lat)*math.Cos(eps) + math.Cos(lat)*math.Sin(eps)*math.Sin(lon))
+
+	return normalizeDegrees(degrees(rightAscension)), degrees(declination)
+}
+
+// EquatorialToEcliptic converts right ascension and declination to ecliptic longitude, in [0, 360), and latitude
+// for the given obliquity of the ecliptic. All angles are in degrees.
+func EquatorialToEcliptic(rightAscension, declination, obliquity float64) (longitude, latitude float64) {
+	ra, dec, eps := radians(rightAscension), radians(declination), radians(obliquity)
+
+	longitude = math.Atan2(math.Sin(ra)*math.Cos(eps)+math.Tan(dec)*math.Sin(eps), math.Cos(ra))
+	latitude = math.Asin(math.Sin(dec)*math.Cos(eps) - math.Cos(dec)*math.Sin(eps)*math.Sin(ra))
+
+	return normalizeDegrees(degrees(longitude)), degrees(latitude)
+}
+
+// EquatorialToHorizontal converts a local hour angle and declination to azimuth, clockwise from north in [0, 360),
+// and elevation above the horizon for an observer at the given latitude. All angles are in degrees.
+func EquatorialToHorizontal(hourAngle, declination, latitude float64) (azimuth, elevation float64) {
+	azimuth, elevation = rotateMeridian(hourAngle, declination, latitude)
+	return normalizeDegrees(azimuth), elevation
+}
+
+// HorizontalToEquatorial converts azimuth, clockwise from north, and elevation to a local hour angle, in
+// [-180, 180], and declination for an observer at the given latitude. All angles are in degrees.
+func HorizontalToEquatorial(azimuth, elevation, latitude float64) (hourAngle, declination float64) {
+	return rotateMeridian(azimuth, elevation, latitude)
+}
+
+// rotateMeridian rotates spherical coordinates around the east-west axis by the colatitude. The rotation is its own
+// inverse, so it converts both from equatorial to horizontal coordinates and back.
+func rotateMeridian(angle, height, latitude float64) (float64, float64) {
+	a, h, phi := radians(angle), radians(height), radians(latitude)
+
+	rotated := math.Atan2(-math.Sin(a)*math.Cos(h), math.Sin(h)*math.Cos(phi)-math.Cos(h)*math.Cos(a)*math.Sin(phi))
+	rotatedHeight := math.Asin(math.Sin(phi)*math.Sin(h) + math.Cos(phi)*math.Cos(h)*math.Cos(a))
+
+	return degrees(rotated), degrees(rotatedHeight)
+}
+
+// greenwichMeanSiderealTime returns the mean sidereal time at Greenwich, in [0, 360) degrees, at the Julian Day jd
+func greenwichMeanSiderealTime(jd float64) float64 {
+	jc := (jd - 2451545) / 36525
+	return normalizeDegrees(280.46061837 + 360.98564736629*(jd-2451545) + 0.000387933*jc*jc - jc*jc*jc/38710000)
+}
+
+// greenwichApparentSiderealTime corrects the mean sidereal time at jd for the nutation in longitude, given with the
+// true obliquity of the ecliptic in degrees
+func greenwichApparentSiderealTime(jd, nutation, obliquity float64) float64 {
+	return normalizeDegrees(greenwichMeanSiderealTime(jd) + nutation*math.Cos(radians(obliquity)))
+}
@@ -0,0 +1,61 @@
+package gosolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSiderealTime checks the example given in the NREL report "Solar Position Algorithm for Solar Radiation
+// Applications" (Reda & Andreas, 2008)
+func TestSiderealTime(t *testing.T) {
+	instant := time.Date(2003, time.October, 17, 12, 30, 30, 0, time.FixedZone("", -7*3600))
+	calc, err := CalculatorFromTime(39.742476, -105.1786, instant, WithEngine(SPA{DeltaT: 67}))
+	require.NoError(t, err)
+
+	assert.InDelta(t, 318.5156, calc.GreenwichMeanSiderealTime(), 1e-4)
+	assert.InDelta(t, 318.5119, calc.GreenwichApparentSiderealTime(), 1e-4)
+	assert.InDelta(t, 202.22741, calc.RightAscension(), 1e-5)
+
+	hourAngle := calc.LocalSiderealTime() - calc.RightAscension()
+	assert.InDelta(t, calc.SunHourAngle(), hourAngle, 1e-6)
+}
+
+func TestSiderealTimeNOAA(t *testing.T) {
+	hourAngle := normalizeDegrees(sc.LocalSiderealTime()-sc.RightAscension()+180) - 180
+	assert.InDelta(t, sc.SunHourAngle(), hourAngle, 0.01)
+}
+
+func TestEclipticEquatorialConversion(t *testing.T) {
+	sun := sc.GetEngine().Sun(sc.JulianDay())
+
+	ra, dec := EclipticToEquatorial(sun.EclipticLongitude, 0, sun.Obliquity)
+	assert.InDelta(t, sun.RightAscension, ra, 1e-9)
+	assert.InDelta(t, sun.Declination, dec, 1e-9)
+
+	lon, lat := EquatorialToEcliptic(ra, dec, sun.Obliquity)
+	assert.InDelta(t, sun.EclipticLongitude, lon, 1e-9)
+	assert.InDelta(t, 0, lat, 1e-9)
+
+	// Meeus, Astronomical Algorithms, example 13.a: Pollux
+	ra, dec = EclipticToEquatorial(113.215630, 6.684170, 23.4392911)
+	assert.InDelta(t, 116.328942, ra, 1e-5)
+	assert.InDelta(t, 28.026183, dec, 1e-5)
+}
+
+func TestEquatorialHorizontalConversion(t *testing.T) {
+	azimuth, elevation := EquatorialToHorizontal(sc.SunHourAngle(), sc.SolarDeclination(), sc.GetLatitude())
+	assert.InDelta(t, sc.SolarAzimuthAngle(), azimuth, 1e-9)
+	assert.InDelta(t, 90-sc.SolarZenithAngle(), elevation, 1e-9)
+
+	hourAngle, declination := HorizontalToEquatorial(azimuth, elevation, sc.GetLatitude())
+	assert.InDelta(t, sc.SunHourAngle(), hourAngle, 1e-9)
+	assert.InDelta(t, sc.SolarDeclination(), declination, 1e-9)
+
+	// Objects on the meridian are due south in the northern hemisphere
+	azimuth, elevation = EquatorialToHorizontal(0, 0, 40)
+	assert.InDelta(t, 180, azimuth, 1e-9)
+	assert.InDelta(t, 50, elevation, 1e-9)
+}
@@ -0,0 +1,148 @@
+package gosolar
+
+import "math"
+
+const (
+	// decompositionMaxZenith is the zenith angle, in degrees, above which decomposition models report no beam
+	decompositionMaxZenith = 87.0
+	// decompositionMinCosZenith bounds the cosine of the zenith angle used for the clearness index near sunset
+	decompositionMinCosZenith = 0.065
+	// decompositionSolarConstant is the solar constant, in W/m², of the clearness index of the Erbs and
+	// Orgill-Hollands models, as in pvlib
+	decompositionSolarConstant = 1366.1
+	// discSolarConstant is the solar constant, in W/m², the DISC model was fitted with
+	discSolarConstant = 1370.0
+	// discMaxAirMass bounds the air mass used by the DISC model
+	discMaxAirMass = 12.0
+)
+
+// DecompositionModel selects the model used to split the global horizontal irradiance into its direct and diffuse
+// components
+type DecompositionModel int
+
+const (
+	// DecompositionErbs is the diffuse fraction correlation of Erbs, Klein and Duffie (1982)
+	DecompositionErbs DecompositionModel = iota
+	// DecompositionOrgillHollands is the diffuse fraction correlation of Orgill and Hollands (1977)
+	DecompositionOrgillHollands
+	// DecompositionDISC is Maxwell's (1987) Direct Insolation Simulation Code model
+	DecompositionDISC
+	// DecompositionDIRINT is the DIRINT model of Perez et al. (1992), which corrects DISC for the stability of the
+	// clearness and the precipitable water. A single sample carries neither, so Decompose uses the coefficients for
+	// unknown values; use DecomposeDIRINT for time series.
+	DecompositionDIRINT
+)
+
+// Decomposition holds the direct and diffuse components estimated from a global horizontal irradiance
+type Decomposition struct {
+	DNI            float64 // direct normal irradiance, in W/m²
+	DHI            float64 // diffuse horizontal irradiance, in W/m²
+	ClearnessIndex float64 // ratio of the global horizontal irradiance to the extraterrestrial one
+}
+
+// ClearnessIndex returns the ratio of the global horizontal irradiance to the extraterrestrial horizontal
+// irradiance, for the given zenith angle in degrees and extraterrestrial normal irradiance in W/m². The cosine of the
+// zenith angle is limited to 0.065 and the result to [0, 2], so it stays meaningful near sunset.
+func ClearnessIndex(ghi, zenith, dniExtra float64) float64 {
+	return clearnessIndex(ghi, zenith, dniExtra, 2)
+}
+
+// Decompose returns the direct normal and diffuse horizontal irradiance estimated from the global horizontal
+// irradiance ghi, in W/m², for the given zenith angle in degrees, day of the year and site pressure in hPa, which
+// only DecompositionDISC and DecompositionDIRINT use. DHI is derived from GHI = DHI + DNI·cos(zenith), and the whole
+// irradiance is reported as diffuse when the zenith angle is above 87°.
+func Decompose(ghi, zenith float64, dayOfYear int, pressure float64, model DecompositionModel) Decomposition {
+	var d Decomposition
+
+	switch model {
+	case DecompositionDISC:
+		d.DNI, d.ClearnessIndex, _ = disc(ghi, zenith, dayOfYear, pressure)
+	case DecompositionDIRINT:
+		var airMass float64
+		d.DNI, d.ClearnessIndex, airMass = disc(ghi, zenith, dayOfYear, pressure)
+		d.DNI *= dirintCoefficient(zenithIndependentClearness(d.ClearnessIndex, airMass), zenith, -1, -1)
+	default:
+		dniExtra := ExtraterrestrialFromDayOfYear(dayOfYear, ExtraterrestrialSpencer) * decompositionSolarConstant / SolarConstant
+		d.ClearnessIndex = clearnessIndex(ghi, zenith, dniExtra, 1)
+		diffuse := diffuseFraction(d.ClearnessIndex, model)
+		d.DNI = (ghi - diffuse*ghi) / math.Cos(radians(zenith))
+	}
+
+	return closeDecomposition(ghi, zenith, d)
+}
+
+// Decompose returns the direct normal and diffuse horizontal irradiance estimated from the global horizontal
+// irradiance ghi, in W/m², for the current SolarZenithAngle, the day of the year of the calculation's date and the
+// site pressure
+func (sc *SolarCalculation) Decompose(ghi float64, model DecompositionModel) Decomposition {
+	return Decompose(ghi, sc.SolarZenithAngle(), sc.dayOfYear(sc.date), sc.sitePressure(), model)
+}
+
+// closeDecomposition reports no beam when the sun is too low or the estimate is invalid, and derives the diffuse
+// horizontal irradiance from the global one
+func closeDecomposition(ghi, zenith float64, d Decomposition) Decomposition {
+	if zenith > decompositionMaxZenith || ghi < 0 || !(d.DNI >= 0) {
+		d.DNI = 0
+	}
+	d.DHI = ghi - d.DNI*math.Cos(radians(zenith))
+	return d
+}
+
+// disc returns the direct normal irradiance of the DISC model, with the clearness index and the absolute air mass,
+// limited to 12, it was computed from
+func disc(ghi, zenith float64, dayOfYear int, pressure float64) (dni, kt, airMass float64) {
+	dniExtra := ExtraterrestrialFromDayOfYear(dayOfYear, ExtraterrestrialSpencer) * discSolarConstant / SolarConstant
+	kt = clearnessIndex(ghi, zenith, dniExtra, 1)
+	airMass = math.Min(AbsoluteAirMass(RelativeAirMass(zenith, AirMassKasten), pressure), discMaxAirMass)
+	return discBeamTransmittance(kt, airMass) * dniExtra, kt, airMass
+}
+
+// clearnessIndex returns the clearness index limited to [0, maxIndex]
+func clearnessIndex(ghi, zenith, dniExtra, maxIndex float64) float64 {
+	horizontalExtra := dniExtra * math.Max(math.Cos(radians(zenith)), decompositionMinCosZenith)
+	return math.Min(math.Max(ghi/horizontalExtra, 0), maxIndex)
+}
+
+// diffuseFraction returns the ratio of the diffuse to the global horizontal irradiance for a clearness index kt
+func diffuseFraction(kt float64, model DecompositionModel) float64 {
+	if model == DecompositionOrgillHollands {
+		switch {
+		case kt < 0.35:
+			return 1 - 0.249*kt
+		case kt <= 0.75:
+			return 1.557 - 1.84*kt
+		default:
+			return 0.177
+		}
+	}
+
+	switch {
+	case kt <= 0.22:
+		return 1 - 0.09*kt
+	case kt <= 0.8:
+		return 0.9511 + kt*(-0.1604+kt*(4.388+kt*(-16.638+kt*12.336)))
+	default:
+		return 0.165
+	}
+}
+
+// discBeamTransmittance returns the direct beam transmittance of the DISC model, i.e. the ratio of the direct normal
+// to the extraterrestrial irradiance, for a clearness index kt and absolute air mass
+func discBeamTransmittance(kt, airMass float64) float64 {
+	am := math.Min(airMass, discMaxAirMass)
+
+	var a, b, c float64
+	if kt <= 0.6 {
+		a = 0.512 + kt*(-1.56+kt*(2.286-2.222*kt))
+		b = 0.37 + 0.962*kt
+		c = -0.28 + kt*(0.932-2.048*kt)
+	} else {
+		a = -5.743 + kt*(21.77+kt*(-27.49+11.56*kt))
+		b = 41.4 + kt*(-118.5+kt*(66.05+31.9*kt))
+		c = -47.01 + kt*(184.2+kt*(-222.0+73.81*kt))
+	}
+
+	// Transmittance of a clear sky minus its reduction for the given clearness
+	clear := 0.866 + am*(-0.122+am*(0.0121+am*(-0.000653+1.4e-05*am)))
+	return clear - (a + b*math.Exp(c*am))
+}
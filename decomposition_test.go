@@ -0,0 +1,159 @@
+package gosolar
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearnessIndex(t *testing.T) {
+	assert.InDelta(t, 0.5, ClearnessIndex(500, 0, 1000), 1e-12)
+	assert.InDelta(t, 1, ClearnessIndex(500, 60, 1000), 1e-12)
+
+	// Near and below the horizon the cosine is limited and the index capped
+	assert.InDelta(t, 2, ClearnessIndex(500, 89, 1000), 1e-12)
+	assert.Equal(t, 0.0, ClearnessIndex(-5, 30, 1000))
+}
+
+func TestDecomposeDISC(t *testing.T) {
+	// Reference values from pvlib's test suite, for 2014-06-24 in Golden, Colorado
+	noon := Decompose(1038.62, 10.567, 175, 931.93, DecompositionDISC)
+	assert.InDelta(t, 830.46, noon.DNI, 0.01)
+
+	evening := Decompose(254.53, 72.469, 175, 931.93, DecompositionDISC)
+	assert.InDelta(t, 676.09, evening.DNI, 0.01)
+}
+
+func TestDecomposeErbs(t *testing.T) {
+	// Reference values from test_erbs in pvlib's test_irradiance.py, whose samples are on 2019-01-01 (day 1) and
+	// 2019-06-20 (day 171)
+	d := Decompose(50, 85, 1, SeaLevelPressure, DecompositionErbs)
+	assert.InDelta(t, 96.7192672, d.DNI, 1e-6)
+	assert.InDelta(t, 41.5703604, d.DHI, 1e-6)
+	assert.InDelta(t, 0.405723511, d.ClearnessIndex, 1e-9)
+
+	d = Decompose(1000, 10, 1, SeaLevelPressure, DecompositionErbs)
+	assert.InDelta(t, 794.205651, d.DNI, 1e-6)
+	assert.InDelta(t, 217.860117, d.DHI, 1e-6)
+	assert.InDelta(t, 0.718132729, d.ClearnessIndex, 1e-9)
+
+	d = Decompose(1000, 10, 171, SeaLevelPressure, DecompositionErbs)
+	assert.InDelta(t, 842.001578, d.DNI, 1e-6)
+	assert.InDelta(t, 170.790318, d.DHI, 1e-6)
+	assert.InDelta(t, 0.768214312, d.ClearnessIndex, 1e-9)
+}
+
+func TestDecomposeOrgillHollands(t *testing.T) {
+	// Reference values from test_orgill_hollands in pvlib's test_irradiance.py, which uses the samples of test_erbs
+	d := Decompose(50, 85, 1, SeaLevelPressure, DecompositionOrgillHollands)
+	assert.InDelta(t, 108.731366, d.DNI, 1e-6)
+	assert.InDelta(t, 40.523437, d.DHI, 1e-6)
+	assert.InDelta(t, 0.405723511, d.ClearnessIndex, 1e-9)
+
+	d = Decompose(1000, 10, 1, SeaLevelPressure, DecompositionOrgillHollands)
+	assert.InDelta(t, 776.155771, d.DNI, 1e-6)
+	assert.InDelta(t, 235.635779, d.DHI, 1e-6)
+
+	// Clear sky
+	d = Decompose(1000, 10, 171, SeaLevelPressure, DecompositionOrgillHollands)
+	assert.InDelta(t, 835.696102, d.DNI, 1e-6)
+	assert.InDelta(t, 177, d.DHI, 1e-6)
+}
+
+func TestDecomposeDIRINT(t *testing.T) {
+	// Reference values from pvlib's test suite, which checks them to one decimal, for 2014-06-24 in Golden, Colorado
+	zone := time.FixedZone("", -7*3600)
+	times := []time.Time{time.Date(2014, time.June, 24, 12, 0, 0, 0, zone), time.Date(2014, time.June, 24, 18, 0, 0, 0, zone)}
+	ghi := []float64{1038.62, 254.53}
+	zenith := []float64{10.567, 72.469}
+
+	d, err := DecomposeDIRINT(ghi, zenith, times, 931.93, nil)
+	require.NoError(t, err)
+	assert.InDelta(t, 868.8, d[0].DNI, 0.1)
+	assert.InDelta(t, 699.7, d[1].DNI, 0.1)
+	assert.InDelta(t, ghi[1], d[1].DHI+d[1].DNI*math.Cos(radians(zenith[1])), 1e-9)
+
+	d, err = DecomposeDIRINT(ghi, zenith, times, 931.93, []float64{10, 10})
+	require.NoError(t, err)
+	assert.InDelta(t, 882.1, d[0].DNI, 0.1)
+	assert.InDelta(t, 672.6, d[1].DNI, 0.1)
+
+	// Without neighbours the change in clearness is unknown
+	noon := Decompose(ghi[0], zenith[0], 175, 931.93, DecompositionDIRINT)
+	assert.InDelta(t, 861.9, noon.DNI, 0.1)
+	evening := Decompose(ghi[1], zenith[1], 175, 931.93, DecompositionDIRINT)
+	assert.InDelta(t, 670.4, evening.DNI, 0.1)
+
+	single, err := DecomposeDIRINT(ghi[:1], zenith[:1], times[:1], 931.93, nil)
+	require.NoError(t, err)
+	assert.Equal(t, noon, single[0])
+
+	_, err = DecomposeDIRINT(ghi, zenith[:1], times, 931.93, nil)
+	assert.ErrorIs(t, err, ErrDecompositionLength)
+	_, err = DecomposeDIRINT(ghi, zenith, times, 931.93, []float64{10})
+	assert.ErrorIs(t, err, ErrDecompositionLength)
+}
+
+func TestDecomposeDIRINTPersistence(t *testing.T) {
+	// A morning turning cloudy, whose changes in clearness fall in the first, fourth and fifth stability bins. Expected
+	// values were computed outside this package by a transcription of pvlib's dirint, as its test suite has no such case
+	zone := time.FixedZone("", -7*3600)
+	times := make([]time.Time, 4)
+	for i := range times {
+		times[i] = time.Date(2014, time.June, 24, 9+i, 0, 0, 0, zone)
+	}
+	ghi := []float64{822.0, 880.3, 760.8, 560.2}
+	zenith := []float64{32.4, 24.1, 17.3, 14.9}
+
+	d, err := DecomposeDIRINT(ghi, zenith, times, 931.93, nil)
+	require.NoError(t, err)
+	for i, expected := range []float64{718.598, 599.885, 338.013, 120.235} {
+		assert.InDelta(t, expected, d[i].DNI, 1e-3, "sample %d", i)
+		assert.NotEqual(t, Decompose(ghi[i], zenith[i], 175, 931.93, DecompositionDIRINT).DNI, d[i].DNI, "sample %d", i)
+	}
+}
+
+func TestDIRINTCoefficients(t *testing.T) {
+	// Spot checks from pvlib's test suite
+	assert.Equal(t, 0.385230, dirintCoefficients[0][0][0][0])
+	assert.Equal(t, 0.229970, dirintCoefficients[0][1][2][1])
+	assert.Equal(t, 1.032260, dirintCoefficients[3][2][6][3])
+}
+
+func TestDecomposeDIRINTNight(t *testing.T) {
+	times := make([]time.Time, 3)
+	for i := range times {
+		times[i] = time.Date(2023, time.June, 21, 4+i, 0, 0, 0, time.UTC)
+	}
+
+	d, err := DecomposeDIRINT([]float64{0, 5, 80}, []float64{120, 89, 80}, times, SeaLevelPressure, nil)
+	require.NoError(t, err)
+	for i, ghi := range []float64{0, 5} {
+		assert.Equal(t, 0.0, d[i].DNI)
+		assert.Equal(t, ghi, d[i].DHI)
+	}
+	assert.Greater(t, d[2].DNI, 0.0)
+}
+
+func TestDecomposeLowSun(t *testing.T) {
+	for _, model := range []DecompositionModel{DecompositionErbs, DecompositionOrgillHollands, DecompositionDISC, DecompositionDIRINT} {
+		d := Decompose(50, 88, 175, SeaLevelPressure, model)
+		assert.Equal(t, 0.0, d.DNI)
+		assert.Equal(t, 50.0, d.DHI)
+
+		night := Decompose(0, 120, 175, SeaLevelPressure, model)
+		assert.Equal(t, 0.0, night.DNI)
+		assert.Equal(t, 0.0, night.DHI)
+	}
+}
+
+func TestDecomposeMethod(t *testing.T) {
+	clearSky := sc.ClearSkyIneichen(3)
+	d := sc.Decompose(clearSky.GHI, DecompositionDISC)
+
+	assert.Equal(t, Decompose(clearSky.GHI, sc.SolarZenithAngle(), 1, PressureFromElevation(0), DecompositionDISC), d)
+	assert.InEpsilon(t, clearSky.DNI, d.DNI, 0.15)
+}
@@ -0,0 +1,54 @@
+package gosolar
+
+import "math"
+
+// Engine computes the geocentric apparent position of the sun. SolarCalculation delegates SolarDeclination and
+// EquationOfTime, and therefore every angle and time derived from them, to its Engine. The NOAA chain of methods
+// (GeomMeanLongSun, SunApparentLongitude, ObliqueCorrection, ...) always uses NOAA's formulas.
+type Engine interface {
+	// Sun returns the position of the sun at the Julian Day jd (UT)
+	Sun(jd float64) SunCoordinates
+}
+
+// SunCoordinates holds the geocentric apparent position of the sun at an instant
+type SunCoordinates struct {
+	EclipticLongitude float64 // apparent ecliptic longitude, in degrees
+	Obliquity         float64 // true obliquity of the ecliptic, in degrees
+	Nutation          float64 // nutation in longitude, in degrees
+	RightAscension    float64 // apparent right ascension, in [0, 360) degrees
+	Declination       float64 // apparent declination, in degrees
+	RadiusVector      float64 // Sun–Earth distance, in AU
+	EquationOfTime    float64 // apparent minus mean solar time, in minutes
+}
+
+// engine returns the Engine used by the calculation, NOAA when none has been set
+func (sc *SolarCalculation) engine() Engine {
+	if sc.sunEngine == nil {
+		return NOAA{}
+	}
+	return sc.sunEngine
+}
+
+// sun returns the position of the sun for the calculation's instant
+func (sc *SolarCalculation) sun() SunCoordinates {
+	return sc.cache.sun
+}
+
+// radians converts an angle in degrees to radians
+func radians(degrees float64) float64 {
+	return degrees * (math.Pi / 180.0)
+}
+
+// degrees converts an angle in radians to degrees
+func degrees(radians float64) float64 {
+	return radians * (180.0 / math.Pi)
+}
+
+// normalizeDegrees limits an angle to [0, 360) degrees
+func normalizeDegrees(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
@@ -0,0 +1,77 @@
+package gosolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNOAAEngine(t *testing.T) {
+	sun := NOAA{}.Sun(sc.JulianDay())
+
+	assert.Equal(t, sc.SolarDeclination(), sun.Declination)
+	assert.Equal(t, sc.EquationOfTime(), sun.EquationOfTime)
+	assert.Equal(t, sc.SunApparentLongitude(), sun.EclipticLongitude)
+	assert.Equal(t, sc.ObliqueCorrection(), sun.Obliquity)
+	assert.InDelta(t, 281.973, sun.RightAscension, 1e-3)
+	assert.InDelta(t, 0.98333, sun.RadiusVector, 1e-4)
+	assert.IsType(t, NOAA{}, sc.GetEngine())
+}
+
+// TestSPAEngine checks the example given in the NREL report "Solar Position Algorithm for Solar Radiation
+// Applications" (Reda & Andreas, 2008)
+func TestSPAEngine(t *testing.T) {
+	instant := time.Date(2003, time.October, 17, 12, 30, 30, 0, time.FixedZone("", -7*3600))
+	calc, err := CalculatorFromTime(39.742476, -105.1786, instant, WithEngine(SPA{DeltaT: 67}))
+	require.NoError(t, err)
+
+	jd := calc.JulianDay()
+	assert.InDelta(t, 2452930.312847, jd, 1e-6)
+
+	jme := (jd + 67.0/86400 - 2451545) / 365250
+	assert.InDelta(t, 24.0182616917, normalizeDegrees(degrees(spaEarthValue(spaLTerms, jme))), 1e-9)
+	assert.InDelta(t, -0.0001011219, degrees(spaEarthValue(spaBTerms, jme)), 1e-9)
+
+	deltaPsi, deltaEpsilon := spaNutation(jme * 10)
+	assert.InDelta(t, -0.00399840, deltaPsi, 1e-8)
+	assert.InDelta(t, 0.00166657, deltaEpsilon, 1e-8)
+
+	sun := calc.GetEngine().Sun(jd)
+	assert.InDelta(t, 0.9965422974, sun.RadiusVector, 1e-9)
+	assert.InDelta(t, 23.440465, sun.Obliquity, 1e-6)
+	assert.InDelta(t, 204.0085519281, sun.EclipticLongitude, 1e-9)
+	assert.InDelta(t, 202.22741, sun.RightAscension, 1e-5)
+	assert.InDelta(t, -9.31434, sun.Declination, 1e-5)
+	assert.InDelta(t, 14.641503, sun.EquationOfTime, 0.005)
+
+	assert.InDelta(t, 11.105900, calc.SunHourAngle(), 1e-5)
+	assert.InDelta(t, 194.34024, calc.SolarAzimuthAngle(), 1e-4)
+}
+
+func TestSetEngine(t *testing.T) {
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01")
+	require.NoError(t, err)
+
+	calc.SetEngine(SPA{DeltaT: 69})
+	assert.InDelta(t, sc.SolarDeclination(), calc.SolarDeclination(), 0.01)
+	assert.InDelta(t, sc.SolarZenithAngle(), calc.SolarZenithAngle(), 0.01)
+
+	calc.SetEngine(nil)
+	assert.Equal(t, sc.SolarDeclination(), calc.SolarDeclination())
+}
+
+func TestSPAEngineDistantYears(t *testing.T) {
+	// Julian Days of noon UTC on June 21st, in the proleptic Gregorian calendar
+	for year, jd := range map[int]float64{1500: 2269095, 2200: 2524765, 2500: 2634338} {
+		instant := time.Date(year, time.June, 21, 12, 0, 0, 0, time.UTC)
+		calc, err := CalculatorFromTime(39.742476, -105.1786, instant, WithEngine(SPA{DeltaT: 69}))
+		require.NoError(t, err)
+
+		assert.InDelta(t, jd, calc.JulianDay(), 1e-9, "year %d", year)
+		assert.Equal(t, SPA{DeltaT: 69}.Sun(julianDayOf(instant)).Declination, calc.SolarDeclination(), "year %d", year)
+		// Around the June solstice the declination is close to the obliquity of the ecliptic
+		assert.InDelta(t, 23.44, calc.SolarDeclination(), 0.1, "year %d", year)
+	}
+}
@@ -0,0 +1,94 @@
+package gosolar
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var (
+	// ErrLatitudeRange is returned when a latitude is outside [-90, 90] degrees
+	ErrLatitudeRange = errors.New("latitude must be between -90 and 90 degrees")
+	// ErrLongitudeRange is returned when a longitude is outside [-180, 180] degrees
+	ErrLongitudeRange = errors.New("longitude must be between -180 and 180 degrees")
+	// ErrInvalidDate is returned when a date is not a valid date in format YYYY-MM-DD
+	ErrInvalidDate = errors.New("date must be a valid date in format YYYY-MM-DD")
+	// ErrUnknownTimeZone is returned when a timezone id is not found in the IANA Time Zone database
+	ErrUnknownTimeZone = errors.New("unknown time zone")
+	// ErrDayTimeRange is returned when a fractional time of the day is outside [0, 1]
+	ErrDayTimeRange = errors.New("dayTime must be between 0 and 1")
+	// ErrTimeZoneOffsetRange is returned when a timezone offset is outside [-12, 14] hours
+	ErrTimeZoneOffsetRange = errors.New("time zone offset must be between -12 and 14 hours")
+	// ErrElevationRange is returned when a site elevation is outside [-500, 9000] meters
+	ErrElevationRange = errors.New("elevation must be between -500 and 9000 meters")
+	// ErrObserverHeightRange is returned when an observer height is outside [0, 1000] meters
+	ErrObserverHeightRange = errors.New("observer height must be between 0 and 1000 meters")
+	// ErrPressureRange is returned when an atmospheric pressure is not positive
+	ErrPressureRange = errors.New("pressure must be greater than 0 hPa")
+	// ErrTemperatureRange is returned when a temperature is not above absolute zero
+	ErrTemperatureRange = errors.New("temperature must be above -273.15 °C")
+)
+
+// ValidationError reports an input rejected by a constructor or a setter. Err is one of the Err* sentinels, so
+// the error can be matched with errors.Is, and Value holds the offending input. Cause, when set, is the underlying
+// error, e.g. the one returned by time.LoadLocation.
+type ValidationError struct {
+	Err   error
+	Value any
+	Cause error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%v: %v: %v", e.Err, e.Value, e.Cause)
+	}
+	return fmt.Sprintf("%v: %v", e.Err, e.Value)
+}
+
+// Unwrap returns the sentinel error and the underlying cause, if any
+func (e *ValidationError) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.Err, e.Cause}
+	}
+	return []error{e.Err}
+}
+
+// invalid returns a ValidationError for the sentinel err and the offending value
+func invalid(err error, value any) error {
+	return &ValidationError{Err: err, Value: value}
+}
+
+// datePattern matches dates in format YYYY-MM-DD
+var datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// validateDate checks that date is a valid calendar date in format YYYY-MM-DD
+func validateDate(date string) error {
+	if !datePattern.MatchString(date) {
+		return invalid(ErrInvalidDate, date)
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return &ValidationError{Err: ErrInvalidDate, Value: date, Cause: err}
+	}
+	return nil
+}
+
+// loadLocation loads an IANA timezone, reporting failures as ErrUnknownTimeZone
+func loadLocation(timeZone string) (*time.Location, error) {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, &ValidationError{Err: ErrUnknownTimeZone, Value: timeZone, Cause: err}
+	}
+	return location, nil
+}
+
+// validateAtmosphere checks the pressure, in hPa, and temperature, in °C, used for atmospheric refraction
+func validateAtmosphere(pressure, temperature float64) error {
+	if pressure <= 0 {
+		return invalid(ErrPressureRange, pressure)
+	}
+	if temperature <= -273.15 {
+		return invalid(ErrTemperatureRange, temperature)
+	}
+	return nil
+}
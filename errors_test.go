@@ -0,0 +1,56 @@
+package gosolar
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculatorValidationErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		dayTime   float64
+		timeZone  string
+		date      string
+		want      error
+		value     any
+	}{
+		{"latitude", 91, 0, 0.5, "UTC", "2023-01-01", ErrLatitudeRange, 91.0},
+		{"longitude", 0, -181, 0.5, "UTC", "2023-01-01", ErrLongitudeRange, -181.0},
+		{"date format", 0, 0, 0.5, "UTC", "01-01-2023", ErrInvalidDate, "01-01-2023"},
+		{"date value", 0, 0, 0.5, "UTC", "2023-02-30", ErrInvalidDate, "2023-02-30"},
+		{"dayTime", 0, 0, 1.5, "UTC", "2023-01-01", ErrDayTimeRange, 1.5},
+		{"time zone", 0, 0, 0.5, "Mars/Olympus_Mons", "2023-01-01", ErrUnknownTimeZone, "Mars/Olympus_Mons"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc, err := Calculator(tt.latitude, tt.longitude, tt.dayTime, tt.timeZone, tt.date)
+			assert.Nil(t, calc)
+			require.ErrorIs(t, err, tt.want)
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.value, validationErr.Value)
+		})
+	}
+}
+
+func TestSetterValidationErrors(t *testing.T) {
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, calc.SetLatitude(-90.5), ErrLatitudeRange)
+	assert.ErrorIs(t, calc.SetLongitude(180.5), ErrLongitudeRange)
+	assert.ErrorIs(t, calc.SetDate("2023-13-01"), ErrInvalidDate)
+	assert.ErrorIs(t, calc.SetDayTime(-0.1), ErrDayTimeRange)
+	assert.ErrorIs(t, calc.SetTimeZone("Nowhere/Land"), ErrUnknownTimeZone)
+
+	assert.Equal(t, 23.0975036, calc.GetLatitude())
+	assert.Equal(t, "2023-01-01", calc.GetDate())
+	assert.Equal(t, -5.0, calc.GetTimeZoneOffset())
+}
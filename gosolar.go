@@ -1,37 +1,82 @@
 package gosolar
 
 import (
-	"errors"
-	"fmt"
+	"log/slog"
 	"math"
-	"regexp"
 	"time"
 )
 
+// SolarCalculation computes the position of the sun and the derived times for a site at an instant.
+// Every method except the setters only reads the calculation, so one instance can be shared by goroutines as long
+// as no setter runs at the same time. Use Position, PositionAt or ComputePosition to get plain values instead.
 type SolarCalculation struct {
 	latitude       float64 // float Degrees
 	longitude      float64 // float Degrees
 	date           string  // string "YYYY-MM-DD"
 	dayTime        float64 // float time of the day/24
 	timeZoneOffset float64 // float timezone UTC offset in seconds
+	location       *time.Location
+	dstPolicy      DSTPolicy
+	logger         *slog.Logger
+	pressure       float64 // float atmospheric pressure in hPa
+	pressureSet    bool    // whether the pressure was set rather than left to StandardPressure
+	temperature    float64 // float air temperature in °C
+	elevation      float64 // float site elevation above sea level in meters
+	observerHeight float64 // float observer height above the local terrain in meters
+	sunEngine      Engine
+	cache          cache
 }
 
 // Calculator acts as a constructor for the module. This allows to perform some validations before implementing solarCalculation struct
-func Calculator(latitude, longitude, dayTime float64, timeZone, date string) (*SolarCalculation, error) {
+// The timezone offset is resolved for the given date and time of the day, so DST is applied only when in effect.
+func Calculator(latitude, longitude, dayTime float64, timeZone, date string, opts ...Option) (*SolarCalculation, error) {
 
-	tz, _ := TimeZoneOffset(timeZone)
+	location, err := loadLocation(timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &SolarCalculation{
+		latitude:    latitude,
+		longitude:   longitude,
+		date:        date,
+		dayTime:     dayTime,
+		location:    location,
+		pressure:    StandardPressure,
+		temperature: StandardTemperature,
+	}
+	for _, opt := range opts {
+		opt(sc)
+	}
+
+	if err := sc.validate(); err != nil {
+		return nil, err
+	}
+	if err := sc.resolveOffset(); err != nil {
+		return nil, err
+	}
+	sc.refreshTime()
+	return sc, nil
+}
 
+// CalculatorFromTime acts as a constructor for the module from a time.Time instant. The date, time of the day and
+// timezone offset are derived from t and its Location, so no manual conversion is needed.
+func CalculatorFromTime(latitude, longitude float64, t time.Time, opts ...Option) (*SolarCalculation, error) {
 	sc := &SolarCalculation{
-		latitude:       latitude,
-		longitude:      longitude,
-		date:           date,
-		dayTime:        dayTime,
-		timeZoneOffset: float64(tz) / 3600,
+		latitude:    latitude,
+		longitude:   longitude,
+		pressure:    StandardPressure,
+		temperature: StandardTemperature,
+	}
+	for _, opt := range opts {
+		opt(sc)
 	}
+	sc.setTime(t)
 
 	if err := sc.validate(); err != nil {
 		return nil, err
 	}
+	sc.refreshTime()
 	return sc, nil
 }
 
@@ -40,51 +85,135 @@ func Calculator(latitude, longitude, dayTime float64, timeZone, date string) (*S
 // SetLatitude sets the latitude value in degrees. Valid values are between -90 and 90.
 func (sc *SolarCalculation) SetLatitude(lat float64) error {
 	if lat < -90 || lat > 90 {
-		return errors.New("latitude must be between -90 and 90 degrees")
+		return invalid(ErrLatitudeRange, lat)
 	}
 	sc.latitude = lat
+	sc.refreshSite()
 	return nil
 }
 
 // SetLongitude sets the longitude value in degrees. Valid values are between -180 and 180.
 func (sc *SolarCalculation) SetLongitude(lon float64) error {
 	if lon < -180 || lon > 180 {
-		return errors.New("longitude must be between -180 and 180 degrees")
+		return invalid(ErrLongitudeRange, lon)
 	}
 	sc.longitude = lon
+	sc.refreshSite()
 	return nil
 }
 
-// SetDate sets the calculation date in format YYYY-MM-DD.
+// SetDate sets the calculation date in format YYYY-MM-DD. The timezone offset is resolved again for the new date.
 func (sc *SolarCalculation) SetDate(date string) error {
-	if matched, _ := regexp.MatchString(`\d{4}-\d{2}-\d{2}`, date); !matched {
-		return errors.New("date must be in format YYYY-MM-DD")
+	if err := validateDate(date); err != nil {
+		return err
+	}
+	offset, err := sc.offsetAt(date, sc.dayTime)
+	if err != nil {
+		return err
 	}
 	sc.date = date
+	sc.timeZoneOffset = offset
+	sc.refreshTime()
 	return nil
 }
 
 // SetDayTime sets the fractional time of day between 0 and 1, where 0 represents the start of the day
-// and 1 represents the end of the day.
+// and 1 represents the end of the day. The timezone offset is resolved again for the new time of the day.
 func (sc *SolarCalculation) SetDayTime(dayTime float64) error {
 	if dayTime < 0 || dayTime > 1 {
-		return errors.New("dayTime must be between 0 and 1")
+		return invalid(ErrDayTimeRange, dayTime)
+	}
+	offset, err := sc.offsetAt(sc.date, dayTime)
+	if err != nil {
+		return err
 	}
 	sc.dayTime = dayTime
+	sc.timeZoneOffset = offset
+	sc.refreshTime()
 	return nil
 }
 
 // SetTimeZone sets the timezone using the IANA Time Zone database name (e.g., "America/New_York", "Europe/London").
-// The timezone offset will be calculated automatically.
+// The timezone offset will be calculated automatically for the calculation's date and time of the day.
 func (sc *SolarCalculation) SetTimeZone(timeZone string) error {
-	tzOffset, err := TimeZoneOffset(timeZone)
+	location, err := loadLocation(timeZone)
 	if err != nil {
 		return err
 	}
+	tzOffset, err := zoneOffset(location, sc.date, sc.dayTime, sc.dstPolicy)
+	if err != nil {
+		return err
+	}
+	sc.location = location
 	sc.timeZoneOffset = float64(tzOffset) / 3600
+	sc.refreshTime()
 	return nil
 }
 
+// SetDSTPolicy sets how local times around DST transitions are resolved and resolves the timezone offset again.
+func (sc *SolarCalculation) SetDSTPolicy(policy DSTPolicy) error {
+	previous := sc.dstPolicy
+	sc.dstPolicy = policy
+	if err := sc.resolveOffset(); err != nil {
+		sc.dstPolicy = previous
+		return err
+	}
+	sc.refreshTime()
+	return nil
+}
+
+// SetTime sets the date, time of the day and timezone offset from a time.Time instant. The offset is taken from
+// the Location of t.
+func (sc *SolarCalculation) SetTime(t time.Time) error {
+	_, offset := t.Zone()
+	if offset < -12*3600 || offset > 14*3600 {
+		return invalid(ErrTimeZoneOffsetRange, float64(offset)/3600)
+	}
+	sc.setTime(t)
+	sc.refreshTime()
+	return nil
+}
+
+// SetAtmosphere sets the atmospheric pressure, in hPa, and the air temperature, in °C, used to scale the
+// atmospheric refraction. The pressure also replaces the one derived from the site elevation for the air mass.
+// Pressure must be positive and temperature above absolute zero.
+func (sc *SolarCalculation) SetAtmosphere(pressure, temperature float64) error {
+	if err := validateAtmosphere(pressure, temperature); err != nil {
+		return err
+	}
+	sc.pressure = pressure
+	sc.pressureSet = true
+	sc.temperature = temperature
+	return nil
+}
+
+// SetElevation sets the elevation of the site above sea level, in meters. Valid values are between -500 and 9000.
+// Unless a pressure is set with SetAtmosphere, the elevation sets the site pressure used for the air mass and the
+// refraction at sunrise and sunset.
+func (sc *SolarCalculation) SetElevation(elevation float64) error {
+	if elevation < -500 || elevation > 9000 {
+		return invalid(ErrElevationRange, elevation)
+	}
+	sc.elevation = elevation
+	return nil
+}
+
+// SetObserverHeight sets the height of the observer above the local terrain, in meters, e.g. a rooftop sensor.
+// Valid values are between 0 and 1000.
+func (sc *SolarCalculation) SetObserverHeight(height float64) error {
+	if height < 0 || height > 1000 {
+		return invalid(ErrObserverHeightRange, height)
+	}
+	sc.observerHeight = height
+	return nil
+}
+
+// SetEngine sets the Engine used to compute the position of the sun. A nil engine restores the default NOAA engine.
+func (sc *SolarCalculation) SetEngine(engine Engine) {
+	sc.sunEngine = engine
+	sc.refreshTime()
+}
+
 // Getters
 
 func (sc *SolarCalculation) GetLatitude() float64 {
@@ -111,24 +240,42 @@ func (sc *SolarCalculation) GetTimeZoneOffset() float64 {
 	return sc.timeZoneOffset
 }
 
-// JulianDay calculates the Julian Day number for the current date.
-// It accounts for the time of day and timezone offset.
-// The Julian Day is the continuous count of days since the beginning of the Julian Period.
-// Returns the Julian Day as a float64.
-func (sc *SolarCalculation) JulianDay() float64 {
-	startEpoch := 2415020.5
-	epoch := time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
-	parsedDate, err := time.Parse("2006-01-02", sc.date)
+func (sc *SolarCalculation) GetDSTPolicy() DSTPolicy {
+	return sc.dstPolicy
+}
 
-	if err != nil {
-		return 0
-	}
+func (sc *SolarCalculation) GetEngine() Engine {
+	return sc.engine()
+}
+
+func (sc *SolarCalculation) GetElevation() float64 {
+	return sc.elevation
+}
+
+func (sc *SolarCalculation) GetObserverHeight() float64 {
+	return sc.observerHeight
+}
 
-	// nanoseconds
-	elapsed := parsedDate.Sub(epoch)
-	days := elapsed.Hours() / 24
+func (sc *SolarCalculation) GetPressure() float64 {
+	return sc.pressure
+}
+
+func (sc *SolarCalculation) GetTemperature() float64 {
+	return sc.temperature
+}
 
-	return days + startEpoch + (sc.dayTime - float64(sc.timeZoneOffset)/24)
+// GetTime returns the instant being calculated, in the calculation's Location. When no Location is known, a fixed
+// zone with the calculation's offset is used. A zero time.Time is returned if the date cannot be parsed.
+func (sc *SolarCalculation) GetTime() time.Time {
+	return sc.clockTime(sc.dayTime * 24)
+}
+
+// JulianDay returns the Julian Day number for the current date.
+// It accounts for the time of day and timezone offset, and is computed once whenever they change.
+// The Julian Day is the continuous count of days since the beginning of the Julian Period.
+// Returns the Julian Day as a float64.
+func (sc *SolarCalculation) JulianDay() float64 {
+	return sc.cache.julianDay
 }
 
 // JulianCentury calculates the number of Julian centuries since J2000.0 (January 1, 2000, 12:00 GMT).
@@ -141,53 +288,34 @@ func (sc *SolarCalculation) JulianCentury() float64 {
 // GeomMeanLongSun returns The geometric mean longitude of the Sun, in degrees. For any given date in format YYYY-MM-DD.
 // The result can be corrected for time of the day (0 <= dayTime <=1) and timeZoneOffset (UTC)
 func (sc *SolarCalculation) GeomMeanLongSun() float64 {
-	jCent := sc.JulianCentury()
-	return math.Mod(280.46646+(jCent*(36000.76983+jCent*0.0003032)), 360)
+	return noaaGeomMeanLongSun(sc.JulianCentury())
 }
 
 // GeomMeanAnomSun returns The geometric mean anomaly of the Sun, in degrees. For any given date in format YYYY-MM-DD.
 // The result can be corrected for time of the day (0 <= dayTime <=1) and timeZoneOffset (UTC)
 func (sc *SolarCalculation) GeomMeanAnomSun() float64 {
-	jCent := sc.JulianCentury()
-
-	return 357.52911 + jCent*(35999.05029-0.0001537*jCent)
+	return noaaGeomMeanAnomSun(sc.JulianCentury())
 }
 
 // EccentEarthOrbit returns The eccentricity of the Earth's orbit, in degrees. For any given date in format YYYY-MM-DD.
 // The result can be corrected for time of the day (0 <= dayTime <=1) and timeZoneOffset (UTC)
 func (sc *SolarCalculation) EccentEarthOrbit() float64 {
-	jCent := sc.JulianCentury()
-
-	return 0.016708634 - jCent*(0.000042037+0.0000001267*jCent)
+	return noaaEccentEarthOrbit(sc.JulianCentury())
 }
 
-// EquationOfTime Calculates the value for the equation of time for any given day of the year as found
-// in the official NOAA website
+// EquationOfTime Calculates the value for the equation of time for any given day of the year, in minutes.
+// The default NOAA engine uses the formula found in the official NOAA website
 func (sc *SolarCalculation) EquationOfTime() float64 {
-
-	geomMeanLongSun := sc.GeomMeanLongSun()
-	eccentEarthOrbit := sc.EccentEarthOrbit()
-	varY := 0.043031509
-
-	gmlRad := 2 * sc.toRadians(geomMeanLongSun)
-	gmaRad := sc.toRadians(sc.GeomMeanAnomSun())
-
-	// Mean longitude
-	gmlComp := varY * math.Sin(gmlRad)
-	// Geometric mean anomaly
-	gmaComp := 2 * eccentEarthOrbit * math.Sin(gmaRad)
-	// Eccentricity
-	eccComp := 4 * eccentEarthOrbit * varY * math.Sin(gmaRad) * math.Cos(gmlRad)
-
-	varYComp := 0.5 * math.Pow(varY, 2) * math.Sin(4*sc.toRadians(geomMeanLongSun))
-	eccSqComp := 1.25 * math.Pow(eccentEarthOrbit, 2) * math.Sin(2*gmaRad)
-
-	formula := 4 * sc.toDegrees(gmlComp-gmaComp+eccComp-varYComp-eccSqComp)
+	formula := sc.sun().EquationOfTime
+	if sc.logger != nil {
+		sc.logger.Debug("equation of time", "value", formula)
+	}
 
 	return formula
 }
 
-// SolarNoon returns the solar noon time in hours
+// SolarNoon returns the solar noon time as a fraction of the day, in local clock time at the calculation's
+// timezone offset
 func (sc *SolarCalculation) SolarNoon() float64 {
 	return (720 - 4*sc.longitude - sc.EquationOfTime() + float64(sc.timeZoneOffset)*60) / 1440
 }
@@ -195,78 +323,97 @@ func (sc *SolarCalculation) SolarNoon() float64 {
 // SunEquationOfCenter returns the angular difference between the actual position
 // of the sun in its elliptical orbit and the position it would occupy if its motion were uniform
 func (sc *SolarCalculation) SunEquationOfCenter() float64 {
-	meanAnomaly := sc.GeomMeanAnomSun()
-	jC := sc.JulianCentury()
-
-	term1 := math.Sin(sc.toRadians(meanAnomaly)) * (1.914602 - jC*(0.004817+0.000014*jC))
-	term2 := math.Sin(sc.toRadians(2*meanAnomaly)) * (0.019993 - 0.000101*jC)
-	term3 := math.Sin(sc.toRadians(3*meanAnomaly)) * 0.000289
-
-	return term1 + term2 + term3
+	return noaaSunEquationOfCenter(sc.JulianCentury())
 }
 
 // SunTrueLongitude returns the Sun's true longitude, in degrees
 func (sc *SolarCalculation) SunTrueLongitude() float64 {
-	return sc.GeomMeanLongSun() + sc.SunEquationOfCenter()
+	return noaaSunTrueLongitude(sc.JulianCentury())
+}
+
+// SunTrueAnomaly returns the Sun's true anomaly, in degrees
+func (sc *SolarCalculation) SunTrueAnomaly() float64 {
+	return noaaSunTrueAnomaly(sc.JulianCentury())
+}
+
+// SunRadiusVector returns the distance between the Sun and the Earth in astronomical units (AU), as computed by the
+// calculation's Engine
+func (sc *SolarCalculation) SunRadiusVector() float64 {
+	return sc.sun().RadiusVector
+}
+
+// ApparentSunDiameter returns the angular diameter of the solar disk seen from the Earth, in degrees
+func (sc *SolarCalculation) ApparentSunDiameter() float64 {
+	// The solar disk spans 1919.26 arc seconds at 1 AU
+	return 1919.26 / 3600 / sc.SunRadiusVector()
 }
 
 // TrueSolarTime calculates the true solar time at the specified location and date.
 // True solar time takes into account variations in the Earth's speed of rotation.
-// Returns the true solar time in minutes.
+// Returns the true solar time in minutes, in [0, 1440).
 func (sc *SolarCalculation) TrueSolarTime() float64 {
-	result := sc.dayTime*1440 + sc.EquationOfTime() + 4*sc.longitude - 60*sc.timeZoneOffset
-	return math.Mod(result, 1440)
+	result := math.Mod(sc.dayTime*1440+sc.EquationOfTime()+4*sc.longitude-60*sc.timeZoneOffset, 1440)
+	if result < 0 {
+		result += 1440
+	}
+	return result
 }
 
 // SunApparentLongitude returns the Sun's apparent longitude, in degrees
 func (sc *SolarCalculation) SunApparentLongitude() float64 {
-	sunTrueLongitude := sc.SunTrueLongitude()
-	jC := sc.JulianCentury()
-	return sunTrueLongitude - 0.00569 - 0.00478*math.Sin(sc.toRadians(125.04-1934.136*jC))
+	return noaaSunApparentLongitude(sc.JulianCentury())
 }
 
 // MeanObliqEcliptic returns the mean inclination of Earth's equator with respect to the ecliptic
 func (sc *SolarCalculation) MeanObliqEcliptic() float64 {
-	jC := sc.JulianCentury()
-	term2 := 26.0 + ((21.448 - jC*(46.815+jC*(0.00059-jC*0.001813))) / 60.0)
-
-	return 23.0 + term2/60.0
+	return noaaMeanObliqEcliptic(sc.JulianCentury())
 }
 
 // ObliqueCorrection returns the oblique correction
 func (sc *SolarCalculation) ObliqueCorrection() float64 {
-	jC := sc.JulianCentury()
-	moe := sc.MeanObliqEcliptic()
-	angle := 125.04 - 1934.136*jC
-
-	return moe + 0.00256*math.Cos(sc.toRadians(angle))
+	return noaaObliqueCorrection(sc.JulianCentury())
 }
 
-// SolarDeclination returns the declination in degrees
+// SolarDeclination returns the declination in degrees, as computed by the calculation's Engine
 func (sc *SolarCalculation) SolarDeclination() float64 {
-	oblCorr := sc.toRadians(sc.ObliqueCorrection())
-	sunAppLon := sc.toRadians(sc.SunApparentLongitude())
-	declination := math.Asin(math.Sin(oblCorr) * math.Sin(sunAppLon))
-
-	return sc.toDegrees(declination)
+	return sc.sun().Declination
 }
 
 // SunHourAngle returns the hour angle of the sun in degrees
 func (sc *SolarCalculation) SunHourAngle() float64 {
-	return (sc.TrueSolarTime() / 4) - 180
+	return sc.cache.hourAngle
 }
 
-// HourAngleSunrise returns the hour angle of the sun at sunrise in degrees
+// HourAngleSunrise returns the hour angle of the sun at sunrise in degrees. The sunrise zenith angle accounts for
+// the refraction at the site pressure, which depends on the elevation, and the horizon dip for the observer height.
 func (sc *SolarCalculation) HourAngleSunrise() float64 {
-	declination := sc.toRadians(sc.SolarDeclination())
-	latitude := sc.toRadians(sc.latitude)
+	zenith := sc.sunriseZenith()
+	hourAngle := sc.HourAngleAtZenith(zenith)
+	if sc.logger != nil {
+		sc.logger.Debug("hour angle sunrise", "zenith", zenith, "value", hourAngle)
+	}
 
-	num := math.Cos(sc.toRadians(90.833))
-	cos := math.Cos(latitude) * math.Cos(declination)
-	tang := math.Tan(latitude) * math.Tan(declination)
-	hourAngle := math.Acos(num/cos - tang)
+	return hourAngle
+}
 
-	return sc.toDegrees(hourAngle)
+// HourAngleAtZenith returns the hour angle of the sun, in degrees, when it reaches the given zenith angle in degrees.
+// The sun is at that zenith angle at -hourAngle while rising and at +hourAngle while setting.
+// When the sun never reaches the zenith angle, see DayStateAtZenith, the hour angle is 180 if it stays above all day
+// and 0 if it stays below.
+func (sc *SolarCalculation) HourAngleAtZenith(zenith float64) float64 {
+	hourAngle, _ := sc.hourAngleAtZenith(zenith)
+	return hourAngle
+}
+
+// DayState tells whether the sun rises and sets during the day, or stays up or down all day at high latitudes
+func (sc *SolarCalculation) DayState() DayState {
+	return sc.DayStateAtZenith(sc.sunriseZenith())
+}
+
+// DayStateAtZenith tells whether the sun crosses the given zenith angle, in degrees, during the day
+func (sc *SolarCalculation) DayStateAtZenith(zenith float64) DayState {
+	_, state := sc.hourAngleAtZenith(zenith)
+	return state
 }
 
 // SolarZenithAngle calculates the angle between the vertical (zenith) and the line to the sun, in degrees.
@@ -274,37 +421,14 @@ func (sc *SolarCalculation) HourAngleSunrise() float64 {
 // At sunrise/sunset, the zenith angle is approximately 90°.
 // Returns the solar zenith angle in degrees.
 func (sc *SolarCalculation) SolarZenithAngle() float64 {
-	declination := sc.toRadians(sc.SolarDeclination())
-	latitude := sc.toRadians(sc.latitude)
-	hourAngle := sc.toRadians(sc.SunHourAngle())
-
-	sin := math.Sin(latitude) * math.Sin(declination)
-	cos := math.Cos(latitude) * math.Cos(declination) * math.Cos(hourAngle)
-
-	return sc.toDegrees(math.Acos(sin + cos))
+	return sc.cache.zenith
 }
 
 // SolarAzimuthAngle calculates the compass direction from which the sunlight is coming, in degrees.
 // Azimuth is measured clockwise from north (0°), with east at 90°, south at 180°, and west at 270°.
 // Returns the solar azimuth angle in degrees.
 func (sc *SolarCalculation) SolarAzimuthAngle() float64 {
-	var mod float64
-	hourAngle := sc.SunHourAngle()
-	latitude := sc.toRadians(sc.latitude)
-	zenithAngle := sc.toRadians(sc.SolarZenithAngle())
-	declination := sc.toRadians(sc.SolarDeclination())
-
-	num := (math.Sin(latitude) * math.Cos(zenithAngle)) - math.Sin(declination)
-	cosSin := math.Cos(latitude) * math.Sin(zenithAngle)
-	formula := sc.toDegrees(math.Acos(num / cosSin))
-
-	if hourAngle > 0 {
-		mod = formula + 180
-	} else {
-		mod = 540 - formula
-	}
-
-	return math.Mod(mod, 360)
+	return sc.cache.azimuth
 }
 
 // SolarIncidenceAngle calculates the angle between the sun's rays and the normal to a horizontal surface.
@@ -334,25 +458,17 @@ func (sc *SolarCalculation) SolarIncidenceAngle() float64 {
 //
 // Note: This function integrates several astronomical calculations including solar declination
 // and hour angle to determine the precise sun position relative to the tilted surface.
+//
+// Breaking change: surfaceAzimuth used to be measured from south (0° was south, 180° north), contrary to this
+// documentation. Callers that worked around it must now add 180° to their azimuths.
 func (sc *SolarCalculation) IncidenceOnTiltedSurface(surfaceAngle, surfaceAzimuth float64) float64 {
-	latitude := sc.toRadians(sc.latitude)
-	declination := sc.toRadians(sc.SolarDeclination())
-	azimuth := sc.toRadians(surfaceAzimuth)
-	surfaceAngle = sc.toRadians(surfaceAngle)
-	hourAngle := sc.toRadians(sc.SunHourAngle())
-
-	seasonalTilt := math.Sin(latitude) * math.Sin(declination) * math.Cos(surfaceAngle)
-	azmTerm := math.Cos(latitude) * math.Sin(declination) * math.Cos(azimuth) * math.Sin(surfaceAngle)
-	hourTerm := math.Cos(latitude) * math.Cos(declination) * math.Cos(hourAngle) * math.Cos(surfaceAngle)
-	hourAzim := math.Sin(latitude) * math.Cos(declination) * math.Cos(hourAngle) * math.Sin(surfaceAngle) * math.Cos(azimuth)
-	declAzim := math.Cos(declination) * math.Sin(hourAngle) * math.Sin(surfaceAngle) * math.Sin(azimuth)
-
-	cosAng := seasonalTilt - azmTerm + hourTerm + hourAzim + declAzim
-	angle := math.Acos(cosAng)
-	return sc.toDegrees(angle)
+	return incidenceAngle(sc.latitude, sc.SolarDeclination(), sc.SunHourAngle(), surfaceAngle, surfaceAzimuth)
 }
 
-// SunriseAndSunset returns the sunrise and sunset time as hours in solar time.
+// SunriseAndSunset returns the sunrise and sunset time as hours in local clock time at the calculation's timezone
+// offset. The values may be negative or greater than 24 when the event falls on the previous or next day.
+// During polar day the sun "rises" 12 hours before solar noon and "sets" 12 hours after it, and during polar night
+// both happen at solar noon. Use DayState to tell these cases apart.
 func (sc *SolarCalculation) SunriseAndSunset() (sunrise, sunset float64) {
 	solarNoon := sc.SolarNoon()
 	hourAngle := sc.HourAngleSunrise()
@@ -363,7 +479,23 @@ func (sc *SolarCalculation) SunriseAndSunset() (sunrise, sunset float64) {
 	return sunrise, sunset
 }
 
-// DayLength returns the length of a day in hours
+// TimeAtZenith returns the time, in hours, when the sun reaches the given zenith angle in degrees, either while
+// rising or while setting. The result uses the same time scale as SunriseAndSunset.
+// ErrSunAlwaysUp or ErrSunAlwaysDown is returned when the sun does not reach the zenith angle during the day.
+func (sc *SolarCalculation) TimeAtZenith(zenith float64, motion SunMotion) (float64, error) {
+	solarNoon := sc.SolarNoon()
+	hourAngle, state := sc.hourAngleAtZenith(zenith)
+
+	if err := state.err(); err != nil {
+		return math.NaN(), err
+	}
+	if motion == Rising {
+		return (solarNoon*360 - hourAngle) / 15, nil
+	}
+	return (solarNoon*360 + hourAngle) / 15, nil
+}
+
+// DayLength returns the length of a day in hours. It is 24 during polar day and 0 during polar night.
 func (sc *SolarCalculation) DayLength() float64 {
 	sunrise, sunset := sc.SunriseAndSunset()
 	dayLength := sunset - sunrise
@@ -383,6 +515,31 @@ func (sc *SolarCalculation) SunsetTime() float64 {
 	return sunset
 }
 
+// SunriseDateTime returns the sunrise as a time.Time in the calculation's Location. The sunrise may fall on the
+// previous calendar day. ErrSunAlwaysUp or ErrSunAlwaysDown is returned during polar day or night.
+func (sc *SolarCalculation) SunriseDateTime() (time.Time, error) {
+	sunrise, err := sc.TimeAtZenith(sc.sunriseZenith(), Rising)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sc.clockTime(sunrise), nil
+}
+
+// SunsetDateTime returns the sunset as a time.Time in the calculation's Location. The sunset may fall on the
+// next calendar day. ErrSunAlwaysUp or ErrSunAlwaysDown is returned during polar day or night.
+func (sc *SolarCalculation) SunsetDateTime() (time.Time, error) {
+	sunset, err := sc.TimeAtZenith(sc.sunriseZenith(), Setting)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sc.clockTime(sunset), nil
+}
+
+// SolarNoonDateTime returns the solar noon as a time.Time in the calculation's Location
+func (sc *SolarCalculation) SolarNoonDateTime() time.Time {
+	return sc.clockTime(sc.SolarNoon() * 24)
+}
+
 // EffectiveIrradiance calculates the amount of solar irradiance actually incident on a surface
 // by applying the cosine factor to the horizontal irradiance. This accounts for the reduction
 // in effective irradiance when sunlight strikes a surface at an angle.
@@ -393,6 +550,9 @@ func (sc *SolarCalculation) SunsetTime() float64 {
 //
 // Returns:
 //   - The effective irradiance on the angled surface in the same units as the horizontalIrradiance
+//
+// Note: Only the direct irradiance follows the cosine law. Use PlaneOfArray to include the diffuse and
+// ground-reflected irradiance received by tilted panels.
 func (sc *SolarCalculation) EffectiveIrradiance(horizontalIrradiance float64, incidenceAngleDeg float64) float64 {
 	angleRad := sc.toRadians(incidenceAngleDeg)
 	cosineFactor := math.Cos(angleRad)
@@ -404,11 +564,11 @@ func (sc *SolarCalculation) EffectiveIrradiance(horizontalIrradiance float64, in
 	return horizontalIrradiance * cosineFactor
 }
 
-// TimeZoneOffset returns the offset in seconds for a given TimeZone id
+// TimeZoneOffset returns the current offset in seconds for a given TimeZone id. Use TimeZoneOffsetAt to get the
+// offset in effect at a specific date and time of the day.
 func TimeZoneOffset(timeZoneId string) (int, error) {
-	location, err := time.LoadLocation(timeZoneId)
+	location, err := loadLocation(timeZoneId)
 	if err != nil {
-		fmt.Println("error:", err)
 		return -1, err
 	}
 
@@ -418,6 +578,141 @@ func TimeZoneOffset(timeZoneId string) (int, error) {
 	return offset, nil
 }
 
+// hourAngleAtZenith returns the hour angle of the sun, in degrees, at the given zenith angle and whether the sun
+// crosses that zenith angle at all
+func (sc *SolarCalculation) hourAngleAtZenith(zenith float64) (float64, DayState) {
+	declination := sc.toRadians(sc.SolarDeclination())
+	latitude := sc.toRadians(sc.latitude)
+
+	num := math.Cos(sc.toRadians(zenith))
+	cos := math.Cos(latitude) * math.Cos(declination)
+	tang := math.Tan(latitude) * math.Tan(declination)
+	cosHourAngle := num/cos - tang
+
+	switch {
+	case cosHourAngle < -1:
+		return 180, AlwaysUp
+	case cosHourAngle > 1:
+		return 0, AlwaysDown
+	}
+	return sc.toDegrees(math.Acos(cosHourAngle)), NormalDay
+}
+
+// zenithAngle returns the solar zenith angle, in degrees, from the latitude, declination and hour angle in degrees
+func zenithAngle(latitude, declination, hourAngle float64) float64 {
+	declination = radians(declination)
+	latitude = radians(latitude)
+	hourAngle = radians(hourAngle)
+
+	sin := math.Sin(latitude) * math.Sin(declination)
+	cos := math.Cos(latitude) * math.Cos(declination) * math.Cos(hourAngle)
+
+	return degrees(math.Acos(sin + cos))
+}
+
+// azimuthAngle returns the solar azimuth angle, in degrees clockwise from north, from the latitude, declination,
+// hour angle and zenith angle in degrees
+func azimuthAngle(latitude, declination, hourAngle, zenith float64) float64 {
+	var mod float64
+	latitude = radians(latitude)
+	zenith = radians(zenith)
+	declination = radians(declination)
+
+	num := (math.Sin(latitude) * math.Cos(zenith)) - math.Sin(declination)
+	cosSin := math.Cos(latitude) * math.Sin(zenith)
+	formula := degrees(math.Acos(num / cosSin))
+
+	if hourAngle > 0 {
+		mod = formula + 180
+	} else {
+		mod = 540 - formula
+	}
+
+	return math.Mod(mod, 360)
+}
+
+// incidenceAngle returns the angle, in degrees, between the sun's rays and the normal to a surface tilted by
+// surfaceAngle and facing surfaceAzimuth, clockwise from north, from the latitude, declination and hour angle. All
+// angles are in degrees.
+func incidenceAngle(latitude, declination, hourAngle, surfaceAngle, surfaceAzimuth float64) float64 {
+	latitude = radians(latitude)
+	declination = radians(declination)
+	// The formula measures the surface azimuth from south, positive towards west
+	azimuth := radians(surfaceAzimuth - 180)
+	surfaceAngle = radians(surfaceAngle)
+	hourAngle = radians(hourAngle)
+
+	seasonalTilt := math.Sin(latitude) * math.Sin(declination) * math.Cos(surfaceAngle)
+	azmTerm := math.Cos(latitude) * math.Sin(declination) * math.Cos(azimuth) * math.Sin(surfaceAngle)
+	hourTerm := math.Cos(latitude) * math.Cos(declination) * math.Cos(hourAngle) * math.Cos(surfaceAngle)
+	hourAzim := math.Sin(latitude) * math.Cos(declination) * math.Cos(hourAngle) * math.Sin(surfaceAngle) * math.Cos(azimuth)
+	declAzim := math.Cos(declination) * math.Sin(hourAngle) * math.Sin(surfaceAngle) * math.Sin(azimuth)
+
+	cosAng := seasonalTilt - azmTerm + hourTerm + hourAzim + declAzim
+	angle := math.Acos(cosAng)
+	return degrees(angle)
+}
+
+// julianDayOf returns the Julian Day of an instant
+func julianDayOf(t time.Time) float64 {
+	return float64(t.Unix())/86400 + float64(t.Nanosecond())/86400e9 + 2440587.5
+}
+
+// resolveOffset updates the timezone offset for the current date and time of the day
+func (sc *SolarCalculation) resolveOffset() error {
+	offset, err := sc.offsetAt(sc.date, sc.dayTime)
+	if err != nil {
+		return err
+	}
+	sc.timeZoneOffset = offset
+	return nil
+}
+
+// offsetAt returns the offset in hours that the calculation's Location has at a local date and time of the day.
+// The current offset is kept when no Location is known.
+func (sc *SolarCalculation) offsetAt(date string, dayTime float64) (float64, error) {
+	if sc.location == nil {
+		return sc.timeZoneOffset, nil
+	}
+	offset, err := zoneOffset(sc.location, date, dayTime, sc.dstPolicy)
+	if err != nil {
+		return 0, err
+	}
+	return float64(offset) / 3600, nil
+}
+
+// clockTime converts hours of local clock time, at the calculation's timezone offset and counted from the start of
+// its date, to a time.Time in the calculation's Location. When no Location is known, a fixed zone with the
+// calculation's offset is used. A zero time.Time is returned if the date cannot be parsed.
+func (sc *SolarCalculation) clockTime(hours float64) time.Time {
+	day, err := time.Parse("2006-01-02", sc.date)
+	if err != nil {
+		return time.Time{}
+	}
+
+	offset := int(math.Round(sc.timeZoneOffset * 3600))
+	elapsed := time.Duration(math.Round(hours * float64(time.Hour)))
+
+	location := sc.location
+	if location == nil {
+		location = time.FixedZone("", offset)
+	}
+
+	return day.Add(elapsed - time.Duration(offset)*time.Second).In(location)
+}
+
+// setTime splits t into the date, fractional time of the day and UTC offset used by the calculations
+func (sc *SolarCalculation) setTime(t time.Time) {
+	_, offset := t.Zone()
+	hour, minute, second := t.Clock()
+	seconds := float64(hour*3600+minute*60+second) + float64(t.Nanosecond())/1e9
+
+	sc.date = t.Format("2006-01-02")
+	sc.dayTime = seconds / 86400
+	sc.timeZoneOffset = float64(offset) / 3600
+	sc.location = t.Location()
+}
+
 // toRadians converts an angle in degrees to radians
 func (sc *SolarCalculation) toRadians(degrees float64) float64 {
 	return degrees * (math.Pi / 180.0)
@@ -470,7 +765,6 @@ func (sc *SolarCalculation) toDateFormatted(day int, year int) string {
 
 	startOfYear := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
 	date := startOfYear.AddDate(0, 0, day-1)
-	fmt.Println(date.Format(dateFormat))
 
 	return date.Format(dateFormat)
 }
@@ -482,36 +776,42 @@ func (sc *SolarCalculation) roundTo(value float64, decimals int) float64 {
 
 // validate performs some validations on the SolarCalculation struct
 // validations are: latitude between -90 and 90, longitude between -180 and 180, date in format YYYY-MM-DD,
+// timezone offset between -12 and 14, dayTime between 0 and 1, elevation between -500 and 9000, observer height
+// between 0 and 1000, positive pressure and temperature above absolute zero
 func (sc *SolarCalculation) validate() error {
 	// Validate latitude
 	if sc.latitude < -90 || sc.latitude > 90 {
-		return errors.New("invalid latitude: must be between -90 and 90")
+		return invalid(ErrLatitudeRange, sc.latitude)
 	}
 
 	// Validate longitude
 	if sc.longitude < -180 || sc.longitude > 180 {
-		return errors.New("invalid longitude: must be between -180 and 180")
+		return invalid(ErrLongitudeRange, sc.longitude)
 	}
 
 	// Validate date
-	dateMatch, _ := regexp.MatchString(`\d{4}-\d{2}-\d{2}`, sc.date)
-	if !dateMatch {
-		return errors.New("invalid date format: must be YYYY-MM-DD")
-	}
-	_, err := time.Parse("2006-01-02", sc.date)
-	if err != nil {
-		return errors.New("invalid date: " + err.Error())
+	if err := validateDate(sc.date); err != nil {
+		return err
 	}
 
 	// Validate timeZoneOffset
 	if sc.timeZoneOffset < -12 || sc.timeZoneOffset > 14 {
-		return errors.New("invalid time zone: must be between -12 and 14")
+		return invalid(ErrTimeZoneOffsetRange, sc.timeZoneOffset)
 	}
 
 	// Validate dayTime
 	if sc.dayTime < 0 || sc.dayTime > 1 {
-		return errors.New("invalid dayTime: must be between 0 and 1")
+		return invalid(ErrDayTimeRange, sc.dayTime)
 	}
 
-	return nil
+	// Validate elevation and observer height
+	if sc.elevation < -500 || sc.elevation > 9000 {
+		return invalid(ErrElevationRange, sc.elevation)
+	}
+	if sc.observerHeight < 0 || sc.observerHeight > 1000 {
+		return invalid(ErrObserverHeightRange, sc.observerHeight)
+	}
+
+	// Validate pressure and temperature
+	return validateAtmosphere(sc.pressure, sc.temperature)
 }
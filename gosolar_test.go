@@ -4,101 +4,106 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestDayLength(t *testing.T) {
 	dayLength := sc.DayLength()
-	assert.Equal(t, 10.743499619136012, dayLength)
+	assert.Equal(t, 10.74373405571232, dayLength)
 }
 
 func TestEccentEarthOrbit(t *testing.T) {
 	orbit := sc.EccentEarthOrbit()
-	assert.Equal(t, 0.016698958307359145, orbit)
+	assert.Equal(t, 0.016698958259338058, orbit)
 }
 
 func TestEquationOfTime(t *testing.T) {
 	eot := sc.EquationOfTime()
-	assert.Equal(t, -3.5247395659817014, eot)
+	assert.Equal(t, -3.544316679973873, eot)
 }
 
 func TestGeomMeanAnomSun(t *testing.T) {
 	meanAnom := sc.GeomMeanAnomSun()
-	assert.Equal(t, 8637.721335352362, meanAnom)
+	assert.Equal(t, 8637.762402030992, meanAnom)
 }
 
 func TestGeomMeanLongSun(t *testing.T) {
 	meanLongSun := sc.GeomMeanLongSun()
-	assert.Equal(t, 281.05422334078503, meanLongSun)
+	assert.Equal(t, 281.0952919812571, meanLongSun)
 }
 
 func TestHourAngleSunrise(t *testing.T) {
 	hourAngle := sc.HourAngleSunrise()
-	assert.Equal(t, 80.57624714352008, hourAngle)
+	assert.Equal(t, 80.5780054178424, hourAngle)
 }
 
 func TestIncidenceOnTiltedSurface(t *testing.T) {
-	incidence := sc.IncidenceOnTiltedSurface(45, 10)
-	assert.Equal(t, 28.596313464002552, incidence)
+	incidence := sc.IncidenceOnTiltedSurface(45, 190)
+	assert.Equal(t, 14.797931728942341, incidence)
+
+	// Near noon the sun is almost in front of a south-facing surface tilted by its zenith angle
+	assert.Less(t, sc.IncidenceOnTiltedSurface(sc.SolarZenithAngle(), 180), 10.0)
+	assert.InDelta(t, sc.SolarZenithAngle(), sc.IncidenceOnTiltedSurface(0, 180), 1e-9)
 }
 
 func TestJulianCentury(t *testing.T) {
 	julianCentury := sc.JulianCentury()
-	assert.Equal(t, 0.2300114077116088, julianCentury)
+	assert.Equal(t, 0.2300125484827786, julianCentury)
 }
 
 func TestJulianDay(t *testing.T) {
 	julianDay := sc.JulianDay()
-	assert.Equal(t, 2459946.1666666665, julianDay)
+	assert.Equal(t, 2459946.2083333335, julianDay)
 }
 
 func TestMeanObliqEcliptic(t *testing.T) {
 	obliqEcliptic := sc.MeanObliqEcliptic()
-	assert.Equal(t, 23.436300001887762, obliqEcliptic)
+	assert.Equal(t, 23.436299987052987, obliqEcliptic)
 }
 
 func TestObliqueCorrection(t *testing.T) {
 	obliqueCorrection := sc.ObliqueCorrection()
-	assert.Equal(t, 23.438256281010286, obliqueCorrection)
+	assert.Equal(t, 23.43825632976151, obliqueCorrection)
 }
 
 func TestSolarAzimuthAngle(t *testing.T) {
 	azimuthAngle := sc.SolarAzimuthAngle()
-	assert.Equal(t, 152.20611634980753, azimuthAngle)
+	assert.Equal(t, 169.48392323952328, azimuthAngle)
 }
 
 func TestSolarDeclination(t *testing.T) {
 	declination := sc.SolarDeclination()
-	assert.Equal(t, -22.985319542237658, declination)
+	assert.Equal(t, -22.981821931604518, declination)
 }
 
 func TestSolarIncidenceAngle(t *testing.T) {
 	incidenceAngle := sc.SolarIncidenceAngle()
-	assert.Equal(t, 38.648930569794715, incidenceAngle)
+	assert.Equal(t, 43.218113690191316, incidenceAngle)
 }
 
 func TestSolarNoon(t *testing.T) {
 	solarNoon := sc.SolarNoon()
-	assert.Equal(t, 0.5647273410874872, solarNoon)
+	assert.Equal(t, 0.5230742696388707, solarNoon)
 }
 
 func TestSolarZenithAngle(t *testing.T) {
 	zenithAngle := sc.SolarZenithAngle()
-	assert.Equal(t, 51.351069430205285, zenithAngle)
+	assert.Equal(t, 46.781886309808684, zenithAngle)
 }
 
 func TestSunApparentLongitude(t *testing.T) {
 	apparentLongitude := sc.SunApparentLongitude()
-	assert.Equal(t, 280.9677490971792, apparentLongitude)
+	assert.Equal(t, 281.01021746222955, apparentLongitude)
 }
 
 func TestSunEquationOfCenter(t *testing.T) {
 	equationOfCenter := sc.SunEquationOfCenter()
-	assert.Equal(t, -0.07770108109845357, equationOfCenter)
+	assert.Equal(t, -0.07630149718600819, equationOfCenter)
 }
 
 func TestSunHourAngle(t *testing.T) {
 	hourAngle := sc.SunHourAngle()
-	assert.Equal(t, -23.301842791495403, hourAngle)
+	assert.Equal(t, -8.30673706999346, hourAngle)
 }
 
 func TestSunTrueLongitude(t *testing.T) {
@@ -107,17 +112,103 @@ func TestSunTrueLongitude(t *testing.T) {
 
 func TestSunriseAndSunset(t *testing.T) {
 	sunrise, sunset := sc.SunriseAndSunset()
-	assert.Equal(t, 8.181706376531688, sunrise)
-	assert.Equal(t, 18.9252059956677, sunset)
+	assert.Equal(t, 7.181915443476737, sunrise)
+	assert.Equal(t, 17.925649499189056, sunset)
 }
 
 func TestTrueSolarTime(t *testing.T) {
 	trueSolarTime := sc.TrueSolarTime()
-	assert.Equal(t, 626.7926288340184, trueSolarTime)
+	assert.Equal(t, 686.7730517200262, trueSolarTime)
+}
+
+func TestTrueSolarTimeWrapsBeforeMidnight(t *testing.T) {
+	// At local midnight the clock is ahead of the sun in Havana, so the raw true solar time is negative
+	calc, err := Calculator(23.0975036, -82.4206579, 0, "America/New_York", "2023-01-01")
+	require.NoError(t, err)
+	raw := calc.EquationOfTime() + 4*calc.GetLongitude() - 60*calc.GetTimeZoneOffset()
+	require.Less(t, raw, 0.0)
+
+	assert.InDelta(t, raw+1440, calc.TrueSolarTime(), 1e-9)
+	assert.InDelta(t, calc.TrueSolarTime()/4-180, calc.SunHourAngle(), 1e-9)
+
+	// Shortly before solar midnight the sun is below the horizon, still west of the meridian
+	assert.Greater(t, calc.SolarZenithAngle(), 90.0)
+	assert.Greater(t, calc.SolarAzimuthAngle(), 180.0)
+	assert.InDelta(t, calc.PositionAt(calc.GetTime()).Azimuth, calc.SolarAzimuthAngle(), 1e-6)
 }
 
 func TestTimeZoneOffset(t *testing.T) {
 	tzOff, err := TimeZoneOffset("America/New_York")
 	require.NoError(t, err)
-	assert.Equal(t, -14400, tzOff)
+
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	_, current := time.Now().In(location).Zone()
+	assert.Equal(t, current, tzOff)
+}
+
+func TestCalculatorResolvesOffsetForDate(t *testing.T) {
+	assert.Equal(t, -5.0, sc.GetTimeZoneOffset())
+
+	summer, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-07-01")
+	require.NoError(t, err)
+	assert.Equal(t, -4.0, summer.GetTimeZoneOffset())
+
+	require.NoError(t, summer.SetDate("2023-12-01"))
+	assert.Equal(t, -5.0, summer.GetTimeZoneOffset())
+}
+
+func TestCalculatorFromTime(t *testing.T) {
+	instant := time.Date(2023, time.January, 1, 18, 30, 15, 0, time.FixedZone("EST", -5*3600))
+	calc, err := CalculatorFromTime(23.0975036, -82.4206579, instant)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2023-01-01", calc.GetDate())
+	assert.Equal(t, (18*3600+30*60+15)/86400.0, calc.GetDayTime())
+	assert.Equal(t, -5.0, calc.GetTimeZoneOffset())
+	assert.True(t, instant.Equal(calc.GetTime()))
+	assert.Equal(t, instant.Location(), calc.GetTime().Location())
+}
+
+func TestSetTime(t *testing.T) {
+	calc, err := CalculatorFromTime(23.0975036, -82.4206579, time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	instant := time.Date(2023, time.June, 16, 9, 45, 0, 0, time.FixedZone("", 2*3600))
+	require.NoError(t, calc.SetTime(instant))
+	assert.Equal(t, "2023-06-16", calc.GetDate())
+	assert.Equal(t, 2.0, calc.GetTimeZoneOffset())
+	assert.True(t, instant.Equal(calc.GetTime()))
+
+	assert.Error(t, calc.SetTime(instant.In(time.FixedZone("", -13*3600))))
+}
+
+func TestSunriseAndSunsetDateTime(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	sunrise, err := sc.SunriseDateTime()
+	require.NoError(t, err)
+	assert.Equal(t, location, sunrise.Location())
+	assert.Equal(t, time.Date(2023, time.January, 1, 7, 10, 54, 0, location), sunrise.Truncate(time.Second))
+
+	sunset, err := sc.SunsetDateTime()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, time.January, 1, 17, 55, 32, 0, location), sunset.Truncate(time.Second))
+
+	noon := sc.SolarNoonDateTime()
+	assert.True(t, noon.After(sunrise) && noon.Before(sunset))
+}
+
+func TestSunsetDateTimeCrossesMidnight(t *testing.T) {
+	// Western Alaska keeps UTC-9 but sits far west of its meridian, so the summer sunset happens after midnight
+	calc, err := Calculator(64.5011, -165.4064, 0.5, "America/Nome", "2023-06-21")
+	require.NoError(t, err)
+
+	sunset, err := calc.SunsetDateTime()
+	require.NoError(t, err)
+	assert.Equal(t, 22, sunset.Day())
+
+	_, sunsetHours := calc.SunriseAndSunset()
+	assert.Greater(t, sunsetHours, 24.0)
 }
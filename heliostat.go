@@ -0,0 +1,60 @@
+package gosolar
+
+import "math"
+
+// Heliostat is a mirror that reflects the sun onto a fixed receiver. Positions are in meters, in East-North-Up
+// coordinates relative to any common origin.
+type Heliostat struct {
+	Position Vector // center of the mirror
+	Receiver Vector // point the reflected rays must hit
+}
+
+// Aiming holds the orientation of a surface that follows the sun and the incidence angle of the sun's rays on it.
+// Angles are in degrees and azimuths clockwise from north.
+type Aiming struct {
+	Normal           Vector  // unit normal of the surface
+	SurfaceTilt      float64 // tilt of the surface from horizontal
+	SurfaceAzimuth   float64 // direction the surface faces
+	Incidence        float64 // incidence angle of the sun's rays on the surface
+	CosineEfficiency float64 // cosine of the incidence angle, the share of the beam irradiance intercepted
+}
+
+// Aim returns the orientation of the mirror that reflects the sun, in the direction of the unit vector sun, onto the
+// receiver. The mirror normal bisects the directions of the sun and the receiver. The mirror is stowed facing up,
+// with a cosine efficiency of 0, while the sun is below the horizon.
+func (h Heliostat) Aim(sun Vector) Aiming {
+	if sun.Up < 0 {
+		return aimAt(Vector{Up: 1}, sun)
+	}
+	target := h.Receiver.Sub(h.Position).Unit()
+	return aimAt(sun.Add(target).Unit(), sun)
+}
+
+// AimHeliostat returns the orientation of the heliostat mirror for the current position of the sun
+func (sc *SolarCalculation) AimHeliostat(heliostat Heliostat) Aiming {
+	return heliostat.Aim(sc.SunVector())
+}
+
+// DualAxisTracking returns the orientation of a dual-axis tracker, which points its surface straight at the sun.
+// The tracker is stowed facing up while the sun is below the horizon.
+func (sc *SolarCalculation) DualAxisTracking() Aiming {
+	sun := sc.SunVector()
+	if sun.Up < 0 {
+		return aimAt(Vector{Up: 1}, sun)
+	}
+	return aimAt(sun, sun)
+}
+
+// aimAt returns the aiming of a surface with the given unit normal lit by the sun in the direction of the unit
+// vector sun
+func aimAt(normal, sun Vector) Aiming {
+	tilt, azimuth := normal.ZenithAzimuth()
+
+	return Aiming{
+		Normal:           normal,
+		SurfaceTilt:      tilt,
+		SurfaceAzimuth:   azimuth,
+		Incidence:        Incidence(sun, normal),
+		CosineEfficiency: math.Max(normal.Dot(sun), 0),
+	}
+}
@@ -0,0 +1,59 @@
+package gosolar
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeliostatAim(t *testing.T) {
+	// A receiver 45° up to the north and the sun 45° up to the south: the mirror lies flat
+	heliostat := Heliostat{Position: Vector{North: -100}, Receiver: Vector{Up: 100}}
+	aiming := heliostat.Aim(direction(45, 180))
+
+	assert.InDelta(t, 0, aiming.SurfaceTilt, 1e-6)
+	assert.InDelta(t, 45, aiming.Incidence, 1e-9)
+	assert.InDelta(t, math.Cos(radians(45)), aiming.CosineEfficiency, 1e-12)
+
+	// A receiver straight above the mirror: the normal is halfway between the sun and the zenith
+	heliostat = Heliostat{Receiver: Vector{Up: 50}}
+	aiming = heliostat.Aim(direction(60, 200))
+
+	assert.InDelta(t, 30, aiming.SurfaceTilt, 1e-9)
+	assert.InDelta(t, 200, aiming.SurfaceAzimuth, 1e-9)
+	assert.InDelta(t, 30, aiming.Incidence, 1e-9)
+}
+
+func TestAimHeliostatReflectsOntoReceiver(t *testing.T) {
+	heliostat := Heliostat{Position: Vector{East: 40, North: 120, Up: 2}, Receiver: Vector{Up: 80}}
+	aiming := sc.AimHeliostat(heliostat)
+
+	// The sun's rays reflected by the mirror point at the receiver
+	sun := sc.SunVector()
+	reflected := aiming.Normal.Scale(2 * aiming.Normal.Dot(sun)).Sub(sun)
+	target := heliostat.Receiver.Sub(heliostat.Position).Unit()
+
+	assert.InDelta(t, target.East, reflected.East, 1e-12)
+	assert.InDelta(t, target.North, reflected.North, 1e-12)
+	assert.InDelta(t, target.Up, reflected.Up, 1e-12)
+	assert.InDelta(t, 1, aiming.Normal.Length(), 1e-12)
+	assert.InDelta(t, aiming.Incidence, sc.IncidenceOnTiltedSurface(aiming.SurfaceTilt, aiming.SurfaceAzimuth), 1e-6)
+}
+
+func TestDualAxisTracking(t *testing.T) {
+	aiming := sc.DualAxisTracking()
+
+	assert.InDelta(t, sc.SolarZenithAngle(), aiming.SurfaceTilt, 1e-9)
+	assert.InDelta(t, sc.SolarAzimuthAngle(), aiming.SurfaceAzimuth, 1e-9)
+	assert.InDelta(t, 0, aiming.Incidence, 1e-6)
+	assert.InDelta(t, 1, aiming.CosineEfficiency, 1e-12)
+
+	night, err := Calculator(23.0975036, -82.4206579, 0.05, "America/New_York", "2023-01-01")
+	require.NoError(t, err)
+	stowed := night.DualAxisTracking()
+	assert.Equal(t, Vector{Up: 1}, stowed.Normal)
+	assert.Equal(t, 0.0, stowed.CosineEfficiency)
+	assert.InDelta(t, night.SolarZenithAngle(), stowed.Incidence, 1e-9)
+}
@@ -0,0 +1,52 @@
+package gosolar
+
+import "math"
+
+// SolarConstant is the mean solar irradiance at the top of the atmosphere at 1 AU from the Sun, in W/m²
+const SolarConstant = 1361.0
+
+// ExtraterrestrialModel selects the day-of-year approximation of the Sun–Earth distance used by
+// ExtraterrestrialFromDayOfYear
+type ExtraterrestrialModel int
+
+const (
+	// ExtraterrestrialSpencer is Spencer's (1971) Fourier series
+	ExtraterrestrialSpencer ExtraterrestrialModel = iota
+	// ExtraterrestrialASCE is the single cosine used by the ASCE standardized reference evapotranspiration equation
+	ExtraterrestrialASCE
+)
+
+// ExtraterrestrialFromDayOfYear returns the solar irradiance at the top of the atmosphere on a surface normal to
+// the sun's rays, in W/m², for a day of the year between 1 and 366
+func ExtraterrestrialFromDayOfYear(dayOfYear int, model ExtraterrestrialModel) float64 {
+	var distanceFactor float64
+
+	switch model {
+	case ExtraterrestrialASCE:
+		b := 2 * math.Pi / 365 * float64(dayOfYear)
+		distanceFactor = 1 + 0.033*math.Cos(b)
+	default:
+		b := 2 * math.Pi / 365 * float64(dayOfYear-1)
+		distanceFactor = 1.00011 + 0.034221*math.Cos(b) + 0.00128*math.Sin(b) + 0.000719*math.Cos(2*b) + 0.000077*math.Sin(2*b)
+	}
+
+	return SolarConstant * distanceFactor
+}
+
+// ExtraterrestrialFromRadiusVector returns the solar irradiance at the top of the atmosphere on a surface normal
+// to the sun's rays, in W/m², for a Sun–Earth distance in AU
+func ExtraterrestrialFromRadiusVector(r float64) float64 {
+	return SolarConstant / (r * r)
+}
+
+// ExtraterrestrialIrradiance returns the solar irradiance at the top of the atmosphere on a surface normal to the
+// sun's rays, in W/m², derived from the Sun–Earth distance given by SunRadiusVector
+func (sc *SolarCalculation) ExtraterrestrialIrradiance() float64 {
+	return ExtraterrestrialFromRadiusVector(sc.SunRadiusVector())
+}
+
+// ExtraterrestrialDayOfYear returns the solar irradiance at the top of the atmosphere on a surface normal to the
+// sun's rays, in W/m², approximated from the day of the year of the calculation's date
+func (sc *SolarCalculation) ExtraterrestrialDayOfYear(model ExtraterrestrialModel) float64 {
+	return ExtraterrestrialFromDayOfYear(sc.dayOfYear(sc.date), model)
+}
@@ -0,0 +1,43 @@
+package gosolar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSunRadiusVector(t *testing.T) {
+	// The Earth is close to perihelion in early January
+	assert.InDelta(t, 0.98333, sc.SunRadiusVector(), 1e-4)
+	assert.InDelta(t, 357.7, normalizeDegrees(sc.SunTrueAnomaly()), 0.1)
+	assert.InDelta(t, 0.5422, sc.ApparentSunDiameter(), 1e-4)
+
+	summer, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-07-04")
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0167, summer.SunRadiusVector(), 1e-4)
+	assert.Less(t, summer.ApparentSunDiameter(), sc.ApparentSunDiameter())
+}
+
+func TestExtraterrestrialIrradiance(t *testing.T) {
+	assert.InDelta(t, 1407.5, sc.ExtraterrestrialIrradiance(), 0.5)
+
+	summer, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-07-04")
+	require.NoError(t, err)
+	assert.InDelta(t, 1316.6, summer.ExtraterrestrialIrradiance(), 0.5)
+}
+
+func TestExtraterrestrialFromDayOfYear(t *testing.T) {
+	assert.InDelta(t, 1408.70, ExtraterrestrialFromDayOfYear(1, ExtraterrestrialSpencer), 0.01)
+	assert.InDelta(t, 1405.91, ExtraterrestrialFromDayOfYear(1, ExtraterrestrialASCE), 0.01)
+
+	// Both approximations stay within 0.5% of the radius vector over the year
+	for _, model := range []ExtraterrestrialModel{ExtraterrestrialSpencer, ExtraterrestrialASCE} {
+		assert.InEpsilon(t, sc.ExtraterrestrialIrradiance(), sc.ExtraterrestrialDayOfYear(model), 5e-3)
+	}
+
+	summer, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-07-04")
+	require.NoError(t, err)
+	assert.InEpsilon(t, summer.ExtraterrestrialIrradiance(), summer.ExtraterrestrialDayOfYear(ExtraterrestrialSpencer), 5e-3)
+	assert.Equal(t, ExtraterrestrialFromRadiusVector(summer.SunRadiusVector()), summer.ExtraterrestrialIrradiance())
+}
@@ -0,0 +1,121 @@
+package gosolar
+
+import "math"
+
+// NOAA is the Engine implementing the formulas of NOAA's solar calculations spreadsheet. Its accuracy is about
+// 0.01° for dates between 1800 and 2100. It is the default Engine.
+type NOAA struct{}
+
+// Sun returns the position of the sun at the Julian Day jd using NOAA's formulas
+func (NOAA) Sun(jd float64) SunCoordinates {
+	jCent := (jd - 2451545) / 36525
+
+	appLon := noaaSunApparentLongitude(jCent)
+	oblCorr := noaaObliqueCorrection(jCent)
+
+	return SunCoordinates{
+		EclipticLongitude: appLon,
+		Obliquity:         oblCorr,
+		Nutation:          -0.00478 * math.Sin(radians(125.04-1934.136*jCent)),
+		RightAscension:    noaaRightAscension(appLon, oblCorr),
+		Declination:       noaaDeclination(appLon, oblCorr),
+		RadiusVector:      noaaSunRadiusVector(jCent),
+		EquationOfTime:    noaaEquationOfTime(jCent),
+	}
+}
+
+// noaaGeomMeanLongSun returns the geometric mean longitude of the sun, in degrees
+func noaaGeomMeanLongSun(jCent float64) float64 {
+	return math.Mod(280.46646+(jCent*(36000.76983+jCent*0.0003032)), 360)
+}
+
+// noaaGeomMeanAnomSun returns the geometric mean anomaly of the sun, in degrees
+func noaaGeomMeanAnomSun(jCent float64) float64 {
+	return 357.52911 + jCent*(35999.05029-0.0001537*jCent)
+}
+
+// noaaEccentEarthOrbit returns the eccentricity of the Earth's orbit
+func noaaEccentEarthOrbit(jCent float64) float64 {
+	return 0.016708634 - jCent*(0.000042037+0.0000001267*jCent)
+}
+
+// noaaEquationOfTime returns the equation of time, in minutes
+func noaaEquationOfTime(jCent float64) float64 {
+	geomMeanLongSun := noaaGeomMeanLongSun(jCent)
+	eccentEarthOrbit := noaaEccentEarthOrbit(jCent)
+	varY := 0.043031509
+
+	gmlRad := 2 * radians(geomMeanLongSun)
+	gmaRad := radians(noaaGeomMeanAnomSun(jCent))
+
+	// Mean longitude
+	gmlComp := varY * math.Sin(gmlRad)
+	// Geometric mean anomaly
+	gmaComp := 2 * eccentEarthOrbit * math.Sin(gmaRad)
+	// Eccentricity
+	eccComp := 4 * eccentEarthOrbit * varY * math.Sin(gmaRad) * math.Cos(gmlRad)
+
+	varYComp := 0.5 * math.Pow(varY, 2) * math.Sin(4*radians(geomMeanLongSun))
+	eccSqComp := 1.25 * math.Pow(eccentEarthOrbit, 2) * math.Sin(2*gmaRad)
+
+	return 4 * degrees(gmlComp-gmaComp+eccComp-varYComp-eccSqComp)
+}
+
+// noaaSunEquationOfCenter returns the equation of center of the sun, in degrees
+func noaaSunEquationOfCenter(jCent float64) float64 {
+	meanAnomaly := noaaGeomMeanAnomSun(jCent)
+
+	term1 := math.Sin(radians(meanAnomaly)) * (1.914602 - jCent*(0.004817+0.000014*jCent))
+	term2 := math.Sin(radians(2*meanAnomaly)) * (0.019993 - 0.000101*jCent)
+	term3 := math.Sin(radians(3*meanAnomaly)) * 0.000289
+
+	return term1 + term2 + term3
+}
+
+// noaaSunTrueLongitude returns the true longitude of the sun, in degrees
+func noaaSunTrueLongitude(jCent float64) float64 {
+	return noaaGeomMeanLongSun(jCent) + noaaSunEquationOfCenter(jCent)
+}
+
+// noaaSunTrueAnomaly returns the true anomaly of the sun, in degrees
+func noaaSunTrueAnomaly(jCent float64) float64 {
+	return noaaGeomMeanAnomSun(jCent) + noaaSunEquationOfCenter(jCent)
+}
+
+// noaaSunRadiusVector returns the Sun–Earth distance, in AU
+func noaaSunRadiusVector(jCent float64) float64 {
+	eccent := noaaEccentEarthOrbit(jCent)
+	return (1.000001018 * (1 - eccent*eccent)) / (1 + eccent*math.Cos(radians(noaaSunTrueAnomaly(jCent))))
+}
+
+// noaaSunApparentLongitude returns the apparent longitude of the sun, in degrees
+func noaaSunApparentLongitude(jCent float64) float64 {
+	return noaaSunTrueLongitude(jCent) - 0.00569 - 0.00478*math.Sin(radians(125.04-1934.136*jCent))
+}
+
+// noaaMeanObliqEcliptic returns the mean obliquity of the ecliptic, in degrees
+func noaaMeanObliqEcliptic(jCent float64) float64 {
+	term2 := 26.0 + ((21.448 - jCent*(46.815+jCent*(0.00059-jCent*0.001813))) / 60.0)
+
+	return 23.0 + term2/60.0
+}
+
+// noaaObliqueCorrection returns the corrected obliquity of the ecliptic, in degrees
+func noaaObliqueCorrection(jCent float64) float64 {
+	angle := 125.04 - 1934.136*jCent
+
+	return noaaMeanObliqEcliptic(jCent) + 0.00256*math.Cos(radians(angle))
+}
+
+// noaaRightAscension returns the right ascension of the sun in [0, 360) degrees
+func noaaRightAscension(appLon, oblCorr float64) float64 {
+	lon := radians(appLon)
+	return normalizeDegrees(degrees(math.Atan2(math.Cos(radians(oblCorr))*math.Sin(lon), math.Cos(lon))))
+}
+
+// noaaDeclination returns the declination of the sun, in degrees
+func noaaDeclination(appLon, oblCorr float64) float64 {
+	declination := math.Asin(math.Sin(radians(oblCorr)) * math.Sin(radians(appLon)))
+
+	return degrees(declination)
+}
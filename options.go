@@ -0,0 +1,54 @@
+package gosolar
+
+import "log/slog"
+
+// Option configures optional settings of a SolarCalculation when passed to Calculator or CalculatorFromTime
+type Option func(*SolarCalculation)
+
+// WithDSTPolicy sets how local times around DST transitions are resolved. The default is DSTEarlier.
+func WithDSTPolicy(policy DSTPolicy) Option {
+	return func(sc *SolarCalculation) {
+		sc.dstPolicy = policy
+	}
+}
+
+// WithLogger sets a logger used to trace intermediate values, like JulianDay, EquationOfTime and HourAngleSunrise,
+// at debug level. The calculation is silent, and builds no log attributes, when no logger is set.
+func WithLogger(logger *slog.Logger) Option {
+	return func(sc *SolarCalculation) {
+		sc.logger = logger
+	}
+}
+
+// WithAtmosphere sets the atmospheric pressure, in hPa, and the air temperature, in °C, used to scale the
+// atmospheric refraction. The pressure also replaces the one derived from the site elevation for the air mass. The
+// default is StandardPressure and StandardTemperature.
+func WithAtmosphere(pressure, temperature float64) Option {
+	return func(sc *SolarCalculation) {
+		sc.pressure = pressure
+		sc.pressureSet = true
+		sc.temperature = temperature
+	}
+}
+
+// WithEngine sets the Engine used to compute the position of the sun. The default is NOAA.
+func WithEngine(engine Engine) Option {
+	return func(sc *SolarCalculation) {
+		sc.sunEngine = engine
+	}
+}
+
+// WithElevation sets the elevation of the site above sea level, in meters. Unless a pressure is set with
+// WithAtmosphere, the elevation sets the site pressure used for the air mass and the refraction at sunrise and sunset.
+func WithElevation(elevation float64) Option {
+	return func(sc *SolarCalculation) {
+		sc.elevation = elevation
+	}
+}
+
+// WithObserverHeight sets the height of the observer above the local terrain, in meters
+func WithObserverHeight(height float64) Option {
+	return func(sc *SolarCalculation) {
+		sc.observerHeight = height
+	}
+}
@@ -0,0 +1,35 @@
+package gosolar
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01", WithLogger(logger))
+	require.NoError(t, err)
+	calc.SunriseAndSunset()
+
+	output := buf.String()
+	assert.Contains(t, output, `msg="julian day"`)
+	assert.Contains(t, output, `msg="equation of time"`)
+	assert.Contains(t, output, `msg="hour angle sunrise"`)
+}
+
+func TestWithLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01", WithLogger(logger))
+	require.NoError(t, err)
+	calc.SunriseAndSunset()
+
+	assert.Empty(t, buf.String())
+}
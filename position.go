@@ -0,0 +1,64 @@
+package gosolar
+
+import "time"
+
+// Position is the position of the sun at an instant as seen from a site. It is a plain value, so it can be copied
+// and shared between goroutines freely. Angles are in degrees, the equation of time in minutes and the radius
+// vector in AU.
+type Position struct {
+	Time           time.Time
+	Zenith         float64
+	Azimuth        float64
+	Elevation      float64
+	HourAngle      float64
+	Declination    float64
+	RightAscension float64
+	EquationOfTime float64
+	RadiusVector   float64
+}
+
+// ComputePosition returns the position of the sun at the instant t for a site. It is a pure function that keeps no
+// state between calls, so it is safe to call from many goroutines at once. The options are the ones accepted by
+// CalculatorFromTime.
+func ComputePosition(latitude, longitude float64, t time.Time, opts ...Option) (Position, error) {
+	sc, err := CalculatorFromTime(latitude, longitude, t, opts...)
+	if err != nil {
+		return Position{}, err
+	}
+	return sc.Position(), nil
+}
+
+// Position returns the position of the sun at the calculation's instant
+func (sc *SolarCalculation) Position() Position {
+	return Position{
+		Time:           sc.GetTime(),
+		Zenith:         sc.cache.zenith,
+		Azimuth:        sc.cache.azimuth,
+		Elevation:      90 - sc.cache.zenith,
+		HourAngle:      sc.cache.hourAngle,
+		Declination:    sc.cache.sun.Declination,
+		RightAscension: sc.cache.sun.RightAscension,
+		EquationOfTime: sc.cache.sun.EquationOfTime,
+		RadiusVector:   sc.cache.sun.RadiusVector,
+	}
+}
+
+// PositionAt returns the position of the sun at the instant t for the calculation's location and Engine. Like
+// Series, it neither uses nor modifies the calculation's date, time of the day and timezone offset, so a single
+// calculation can be shared by goroutines evaluating different instants.
+func (sc *SolarCalculation) PositionAt(t time.Time) Position {
+	sun, hourAngle := sc.sunAt(t)
+	zenith := zenithAngle(sc.latitude, sun.Declination, hourAngle)
+
+	return Position{
+		Time:           t,
+		Zenith:         zenith,
+		Azimuth:        azimuthAngle(sc.latitude, sun.Declination, hourAngle, zenith),
+		Elevation:      90 - zenith,
+		HourAngle:      hourAngle,
+		Declination:    sun.Declination,
+		RightAscension: sun.RightAscension,
+		EquationOfTime: sun.EquationOfTime,
+		RadiusVector:   sun.RadiusVector,
+	}
+}
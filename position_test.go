@@ -0,0 +1,112 @@
+package gosolar
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPosition(t *testing.T) {
+	position := sc.Position()
+
+	assert.Equal(t, sc.GetTime(), position.Time)
+	assert.Equal(t, sc.SolarZenithAngle(), position.Zenith)
+	assert.Equal(t, sc.SolarAzimuthAngle(), position.Azimuth)
+	assert.Equal(t, sc.SolarIncidenceAngle(), position.Elevation)
+	assert.Equal(t, sc.SunHourAngle(), position.HourAngle)
+	assert.Equal(t, sc.SolarDeclination(), position.Declination)
+	assert.Equal(t, sc.RightAscension(), position.RightAscension)
+	assert.Equal(t, sc.EquationOfTime(), position.EquationOfTime)
+	assert.Equal(t, sc.SunRadiusVector(), position.RadiusVector)
+}
+
+func TestPositionAtMatchesPosition(t *testing.T) {
+	position := sc.Position()
+	at := sc.PositionAt(sc.GetTime())
+
+	assert.InDelta(t, position.Zenith, at.Zenith, 1e-6)
+	assert.InDelta(t, position.Azimuth, at.Azimuth, 1e-6)
+	assert.InDelta(t, position.HourAngle, at.HourAngle, 1e-6)
+	assert.InDelta(t, position.Declination, at.Declination, 1e-9)
+}
+
+func TestComputePosition(t *testing.T) {
+	instant := sc.GetTime()
+
+	position, err := ComputePosition(23.0975036, -82.4206579, instant)
+	require.NoError(t, err)
+	assert.Equal(t, instant, position.Time)
+	assert.InDelta(t, sc.SolarZenithAngle(), position.Zenith, 1e-6)
+	assert.InDelta(t, sc.SolarAzimuthAngle(), position.Azimuth, 1e-6)
+
+	_, err = ComputePosition(91, 0, instant)
+	assert.ErrorIs(t, err, ErrLatitudeRange)
+}
+
+func TestComputePositionMatchesPositionAtForDistantDates(t *testing.T) {
+	for _, year := range []int{1200, 1600, 2200, 2800} {
+		instant := time.Date(year, time.June, 21, 15, 30, 0, 0, time.UTC)
+
+		position, err := ComputePosition(39.742476, -105.1786, instant, WithEngine(SPA{DeltaT: 69}))
+		require.NoError(t, err)
+		calc, err := CalculatorFromTime(0, 0, instant, WithEngine(SPA{DeltaT: 69}))
+		require.NoError(t, err)
+		require.NoError(t, calc.SetLatitude(39.742476))
+		require.NoError(t, calc.SetLongitude(-105.1786))
+		at := calc.PositionAt(instant)
+
+		assert.InDelta(t, at.Zenith, position.Zenith, 1e-6, "year %d", year)
+		assert.InDelta(t, at.Azimuth, position.Azimuth, 1e-6, "year %d", year)
+		assert.InDelta(t, at.Declination, position.Declination, 1e-9, "year %d", year)
+	}
+}
+
+func TestConcurrentReads(t *testing.T) {
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01")
+	require.NoError(t, err)
+	want := calc.Position()
+	start := calc.GetTime()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.Equal(t, want, calc.Position())
+				calc.PositionAt(start.Add(time.Duration(g*100+i) * time.Minute))
+				calc.IncidenceOnTiltedSurface(30, 180)
+				_, _ = calc.SunriseAndSunset()
+				_, _, _ = calc.RefinedSunriseAndSunset(0)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestConcurrentComputePosition(t *testing.T) {
+	instant := time.Date(2023, time.June, 21, 12, 0, 0, 0, time.UTC)
+	latitudes := []float64{-60, -30, 0, 30, 60}
+
+	positions := make([]Position, len(latitudes))
+	var wg sync.WaitGroup
+	for i, latitude := range latitudes {
+		wg.Add(1)
+		go func(i int, latitude float64) {
+			defer wg.Done()
+			position, err := ComputePosition(latitude, 0, instant, WithEngine(SPA{DeltaT: 69}))
+			assert.NoError(t, err)
+			positions[i] = position
+		}(i, latitude)
+	}
+	wg.Wait()
+
+	for i, latitude := range latitudes {
+		want, err := ComputePosition(latitude, 0, instant, WithEngine(SPA{DeltaT: 69}))
+		require.NoError(t, err)
+		assert.Equal(t, want, positions[i])
+	}
+}
@@ -0,0 +1,73 @@
+package gosolar
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	// DefaultRefinementTolerance is used by the refined event calculations when no positive tolerance is given
+	DefaultRefinementTolerance = time.Second
+	// maxRefinementIterations bounds the number of position evaluations of a refined event calculation
+	maxRefinementIterations = 25
+)
+
+// ErrNotConverged is returned when a refined event time does not converge within the iteration limit
+var ErrNotConverged = errors.New("event time did not converge")
+
+// RefinedEvent is the time of an event computed with the sun position at the event itself
+type RefinedEvent struct {
+	Time       float64 // hours, using the same time scale as SunriseAndSunset
+	Iterations int     // number of position evaluations after the first estimate
+}
+
+// RefinedTimeAtZenith returns the time when the sun reaches the given zenith angle, in degrees, like TimeAtZenith,
+// but evaluates the declination and equation of time at the estimated time of the event instead of the calculation's
+// time of the day. The estimate is iterated until two consecutive times differ by less than tolerance.
+//
+// ErrSunAlwaysUp or ErrSunAlwaysDown is returned when the sun does not reach the zenith angle around the event, and
+// ErrNotConverged, along with the last estimate, when the tolerance is not met.
+func (sc *SolarCalculation) RefinedTimeAtZenith(zenith float64, motion SunMotion, tolerance time.Duration) (RefinedEvent, error) {
+	if tolerance <= 0 {
+		tolerance = DefaultRefinementTolerance
+	}
+	toleranceHours := tolerance.Hours()
+
+	estimate, err := sc.TimeAtZenith(zenith, motion)
+	if err != nil {
+		return RefinedEvent{Time: estimate}, err
+	}
+
+	for i := 1; i <= maxRefinementIterations; i++ {
+		next, err := sc.at(estimate).TimeAtZenith(zenith, motion)
+		if err != nil {
+			return RefinedEvent{Time: next, Iterations: i}, err
+		}
+		if math.Abs(next-estimate) < toleranceHours {
+			return RefinedEvent{Time: next, Iterations: i}, nil
+		}
+		estimate = next
+	}
+
+	return RefinedEvent{Time: estimate, Iterations: maxRefinementIterations}, ErrNotConverged
+}
+
+// RefinedSunriseAndSunset returns the sunrise and sunset like SunriseAndSunset, refined with RefinedTimeAtZenith
+func (sc *SolarCalculation) RefinedSunriseAndSunset(tolerance time.Duration) (sunrise, sunset RefinedEvent, err error) {
+	zenith := sc.sunriseZenith()
+	if sunrise, err = sc.RefinedTimeAtZenith(zenith, Rising, tolerance); err != nil {
+		return sunrise, sunset, err
+	}
+	sunset, err = sc.RefinedTimeAtZenith(zenith, Setting, tolerance)
+	return sunrise, sunset, err
+}
+
+// at returns a copy of the calculation at the given hours of local clock time of its date. Hours outside [0, 24]
+// move the instant to the previous or next days while keeping the date and timezone offset.
+func (sc *SolarCalculation) at(hours float64) *SolarCalculation {
+	c := *sc
+	c.dayTime = hours / 24
+	c.refreshTime()
+	return &c
+}
@@ -0,0 +1,49 @@
+package gosolar
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefinedSunriseAndSunset(t *testing.T) {
+	sunrise, sunset, err := sc.RefinedSunriseAndSunset(time.Second)
+	require.NoError(t, err)
+
+	assert.Greater(t, sunrise.Iterations, 0)
+	assert.LessOrEqual(t, sunrise.Iterations, 5)
+	assert.Greater(t, sunset.Iterations, 0)
+
+	// At the refined times the sun is at the sunrise zenith angle
+	assert.InDelta(t, SunriseZenith, sc.at(sunrise.Time).SolarZenithAngle(), 0.005)
+	assert.InDelta(t, SunriseZenith, sc.at(sunset.Time).SolarZenithAngle(), 0.005)
+
+	estimatedSunrise, estimatedSunset := sc.SunriseAndSunset()
+	assert.InDelta(t, estimatedSunrise, sunrise.Time, 2.0/60)
+	assert.InDelta(t, estimatedSunset, sunset.Time, 2.0/60)
+	assert.NotEqual(t, estimatedSunrise, sunrise.Time)
+}
+
+func TestRefinedTimeAtZenithHighLatitude(t *testing.T) {
+	// Close to the start of the polar night in Tromsø the declination changes quickly relative to the day length
+	calc, err := Calculator(69.6492, 18.9553, 0.5, "Europe/Oslo", "2023-11-20")
+	require.NoError(t, err)
+
+	estimate, err := calc.TimeAtZenith(SunriseZenith, Rising)
+	require.NoError(t, err)
+
+	refined, err := calc.RefinedTimeAtZenith(SunriseZenith, Rising, time.Second)
+	require.NoError(t, err)
+	assert.InDelta(t, SunriseZenith, calc.at(refined.Time).SolarZenithAngle(), 0.005)
+	assert.Greater(t, math.Abs(estimate-refined.Time), 0.5/60)
+
+	tight, err := calc.RefinedTimeAtZenith(SunriseZenith, Rising, time.Millisecond)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, tight.Iterations, refined.Iterations)
+
+	_, err = calc.RefinedTimeAtZenith(AstronomicalTwilightZenith-100, Rising, 0)
+	assert.ErrorIs(t, err, ErrSunAlwaysDown)
+}
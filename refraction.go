@@ -0,0 +1,113 @@
+package gosolar
+
+import "math"
+
+const (
+	// StandardPressure is the atmospheric pressure, in hPa, the refraction models are calibrated for
+	StandardPressure = 1010.0
+	// StandardTemperature is the air temperature, in °C, the refraction models are calibrated for
+	StandardTemperature = 10.0
+)
+
+// RefractionModel selects the formula used to approximate the atmospheric refraction of sunlight
+type RefractionModel int
+
+const (
+	// RefractionNOAA is the piecewise approximation used in NOAA's solar calculations spreadsheet
+	RefractionNOAA RefractionModel = iota
+	// RefractionBennett is Bennett's (1982) formula, defined for the apparent elevation
+	RefractionBennett
+	// RefractionSaemundsson is Sæmundsson's (1986) formula, defined for the geometric elevation
+	RefractionSaemundsson
+)
+
+// Refraction returns the atmospheric refraction, in degrees, for a sun at the given geometric elevation in degrees.
+// The result is scaled for pressure, in hPa, and temperature, in °C, relative to StandardPressure and
+// StandardTemperature. Bennett and Sæmundsson are not defined far below the horizon and return 0 for elevations
+// under -1°.
+func Refraction(elevation, pressure, temperature float64, model RefractionModel) float64 {
+	var refraction float64
+
+	switch model {
+	case RefractionBennett:
+		refraction = bennettRefraction(elevation)
+	case RefractionSaemundsson:
+		refraction = saemundssonRefraction(elevation)
+	default:
+		refraction = noaaRefraction(elevation)
+	}
+
+	return refraction * (pressure / StandardPressure) * ((273 + StandardTemperature) / (273 + temperature))
+}
+
+// AtmosphericRefraction returns the refraction, in degrees, for the current sun elevation, using the calculation's
+// pressure and temperature
+func (sc *SolarCalculation) AtmosphericRefraction(model RefractionModel) float64 {
+	return Refraction(sc.SolarIncidenceAngle(), sc.pressure, sc.temperature, model)
+}
+
+// RefractionCorrectedElevation returns the apparent elevation of the sun above the horizon, in degrees, i.e. the
+// geometric elevation plus the atmospheric refraction
+func (sc *SolarCalculation) RefractionCorrectedElevation(model RefractionModel) float64 {
+	elevation := sc.SolarIncidenceAngle()
+	return elevation + Refraction(elevation, sc.pressure, sc.temperature, model)
+}
+
+// ApparentZenith returns the refraction-corrected zenith angle, in degrees
+func (sc *SolarCalculation) ApparentZenith(model RefractionModel) float64 {
+	return 90 - sc.RefractionCorrectedElevation(model)
+}
+
+// noaaRefraction returns the refraction in degrees as found in the NOAA spreadsheet
+func noaaRefraction(elevation float64) float64 {
+	if elevation > 85 {
+		return 0
+	}
+
+	tan := math.Tan(elevation * math.Pi / 180)
+	var arcSeconds float64
+
+	switch {
+	case elevation > 5:
+		arcSeconds = 58.1/tan - 0.07/math.Pow(tan, 3) + 0.000086/math.Pow(tan, 5)
+	case elevation > -0.575:
+		arcSeconds = 1735 + elevation*(-518.2+elevation*(103.4+elevation*(-12.79+elevation*0.711)))
+	default:
+		arcSeconds = -20.772 / tan
+	}
+
+	return arcSeconds / 3600
+}
+
+// bennettRefraction returns the refraction in degrees using Bennett's formula. As the formula expects the apparent
+// elevation, it is solved by fixed-point iteration from the geometric one.
+func bennettRefraction(elevation float64) float64 {
+	if elevation < -1 {
+		return 0
+	}
+
+	bennett := func(apparent float64) float64 {
+		arcMinutes := 1 / math.Tan((apparent+7.31/(apparent+4.4))*math.Pi/180)
+		return math.Max(arcMinutes, 0) / 60
+	}
+
+	refraction := bennett(elevation)
+	for i := 0; i < 20; i++ {
+		next := bennett(elevation + refraction)
+		if math.Abs(next-refraction) < 1e-10 {
+			return next
+		}
+		refraction = next
+	}
+	return refraction
+}
+
+// saemundssonRefraction returns the refraction in degrees using Sæmundsson's formula
+func saemundssonRefraction(elevation float64) float64 {
+	if elevation < -1 {
+		return 0
+	}
+
+	arcMinutes := 1.02 / math.Tan((elevation+10.3/(elevation+5.11))*math.Pi/180)
+	return math.Max(arcMinutes, 0) / 60
+}
@@ -0,0 +1,47 @@
+package gosolar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefraction(t *testing.T) {
+	// Refraction at the horizon is close to 34' and about 5.3' at 10° elevation
+	assert.InDelta(t, 1735.0/3600, Refraction(0, StandardPressure, StandardTemperature, RefractionNOAA), 1e-12)
+	assert.InDelta(t, 0.483, Refraction(0, StandardPressure, StandardTemperature, RefractionSaemundsson), 0.001)
+	assert.InDelta(t, 0.5, Refraction(0, StandardPressure, StandardTemperature, RefractionBennett), 0.02)
+
+	for _, model := range []RefractionModel{RefractionNOAA, RefractionBennett, RefractionSaemundsson} {
+		assert.InDelta(t, 5.3/60, Refraction(10, StandardPressure, StandardTemperature, model), 0.003)
+		assert.InDelta(t, 0, Refraction(90, StandardPressure, StandardTemperature, model), 1e-4)
+	}
+
+	standard := Refraction(2, StandardPressure, StandardTemperature, RefractionBennett)
+	assert.InDelta(t, standard*0.8, Refraction(2, 0.8*StandardPressure, StandardTemperature, RefractionBennett), 1e-12)
+	assert.Less(t, Refraction(2, StandardPressure, 30, RefractionBennett), standard)
+	assert.Equal(t, 0.0, Refraction(-5, StandardPressure, StandardTemperature, RefractionSaemundsson))
+}
+
+func TestRefractionCorrectedElevation(t *testing.T) {
+	elevation := sc.SolarIncidenceAngle()
+	refraction := sc.AtmosphericRefraction(RefractionNOAA)
+
+	assert.Greater(t, refraction, 0.0)
+	assert.Equal(t, elevation+refraction, sc.RefractionCorrectedElevation(RefractionNOAA))
+	assert.InDelta(t, sc.SolarZenithAngle()-refraction, sc.ApparentZenith(RefractionNOAA), 1e-12)
+}
+
+func TestSetAtmosphere(t *testing.T) {
+	calc, err := Calculator(23.0975036, -82.4206579, 0.3, "America/New_York", "2023-01-01", WithAtmosphere(900, 25))
+	require.NoError(t, err)
+	assert.Equal(t, 900.0, calc.GetPressure())
+	assert.Equal(t, 25.0, calc.GetTemperature())
+
+	assert.ErrorIs(t, calc.SetAtmosphere(0, 10), ErrPressureRange)
+	assert.ErrorIs(t, calc.SetAtmosphere(1010, -300), ErrTemperatureRange)
+
+	_, err = Calculator(23.0975036, -82.4206579, 0.3, "America/New_York", "2023-01-01", WithAtmosphere(-1, 10))
+	assert.ErrorIs(t, err, ErrPressureRange)
+}
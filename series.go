@@ -0,0 +1,83 @@
+package gosolar
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	// ErrSeriesStep is returned when the step of a series is not positive
+	ErrSeriesStep = errors.New("series step must be positive")
+	// ErrSeriesRange is returned when the end of a series is before its start
+	ErrSeriesRange = errors.New("series end must not be before its start")
+)
+
+// Series holds the position of the sun sampled at regular steps. All slices have the same length and the values at
+// index i correspond to Times[i]. Angles are in degrees and the equation of time in minutes.
+type Series struct {
+	Times          []time.Time
+	Zenith         []float64
+	Azimuth        []float64
+	Elevation      []float64
+	Declination    []float64
+	EquationOfTime []float64
+}
+
+// Len returns the number of samples in the series
+func (s *Series) Len() int {
+	return len(s.Times)
+}
+
+// Series returns the position of the sun, for the calculation's location and Engine, from start to end, both
+// included, every step. Each sample is computed straight from its instant, so the calculation's date, time of the
+// day and timezone offset are neither used nor modified. Times keep the Location of start.
+func (sc *SolarCalculation) Series(start, end time.Time, step time.Duration) (*Series, error) {
+	if step <= 0 {
+		return nil, ErrSeriesStep
+	}
+	if end.Before(start) {
+		return nil, ErrSeriesRange
+	}
+
+	n := int(end.Sub(start)/step) + 1
+	series := &Series{
+		Times:          make([]time.Time, n),
+		Zenith:         make([]float64, n),
+		Azimuth:        make([]float64, n),
+		Elevation:      make([]float64, n),
+		Declination:    make([]float64, n),
+		EquationOfTime: make([]float64, n),
+	}
+
+	for i := 0; i < n; i++ {
+		t := start.Add(time.Duration(i) * step)
+		sun, hourAngle := sc.sunAt(t)
+		zenith := zenithAngle(sc.latitude, sun.Declination, hourAngle)
+
+		series.Times[i] = t
+		series.Zenith[i] = zenith
+		series.Azimuth[i] = azimuthAngle(sc.latitude, sun.Declination, hourAngle, zenith)
+		series.Elevation[i] = 90 - zenith
+		series.Declination[i] = sun.Declination
+		series.EquationOfTime[i] = sun.EquationOfTime
+	}
+
+	return series, nil
+}
+
+// sunAt returns the position of the sun and its local hour angle, in degrees, at the instant t
+func (sc *SolarCalculation) sunAt(t time.Time) (SunCoordinates, float64) {
+	sun := sc.engine().Sun(julianDayOf(t))
+
+	utc := t.UTC()
+	hour, minute, second := utc.Clock()
+	minutes := float64(hour*60+minute) + (float64(second)+float64(utc.Nanosecond())/1e9)/60
+
+	trueSolarTime := math.Mod(minutes+sun.EquationOfTime+4*sc.longitude, 1440)
+	if trueSolarTime < 0 {
+		trueSolarTime += 1440
+	}
+
+	return sun, trueSolarTime/4 - 180
+}
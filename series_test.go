@@ -0,0 +1,48 @@
+package gosolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeries(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	start := time.Date(2023, time.January, 1, 6, 0, 0, 0, location)
+	end := time.Date(2023, time.January, 1, 18, 0, 0, 0, location)
+
+	series, err := sc.Series(start, end, 15*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 49, series.Len())
+	assert.True(t, end.Equal(series.Times[48]))
+	assert.Equal(t, location, series.Times[0].Location())
+
+	for i, instant := range series.Times {
+		point, err := CalculatorFromTime(sc.GetLatitude(), sc.GetLongitude(), instant)
+		require.NoError(t, err)
+
+		assert.InDelta(t, point.SolarZenithAngle(), series.Zenith[i], 1e-6)
+		assert.InDelta(t, point.SolarAzimuthAngle(), series.Azimuth[i], 1e-6)
+		assert.InDelta(t, 90-point.SolarZenithAngle(), series.Elevation[i], 1e-6)
+		assert.InDelta(t, point.SolarDeclination(), series.Declination[i], 1e-8)
+		assert.InDelta(t, point.EquationOfTime(), series.EquationOfTime[i], 1e-6)
+	}
+}
+
+func TestSeriesErrors(t *testing.T) {
+	start := time.Date(2023, time.January, 1, 6, 0, 0, 0, time.UTC)
+
+	_, err := sc.Series(start, start.Add(time.Hour), 0)
+	assert.ErrorIs(t, err, ErrSeriesStep)
+
+	_, err = sc.Series(start, start.Add(-time.Hour), time.Minute)
+	assert.ErrorIs(t, err, ErrSeriesRange)
+
+	series, err := sc.Series(start, start, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1, series.Len())
+}
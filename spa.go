@@ -0,0 +1,133 @@
+package gosolar
+
+import "math"
+
+// SPA is the Engine implementing NREL's Solar Position Algorithm (Reda & Andreas, 2008). It accounts for the
+// periodic terms of the Earth's heliocentric position, nutation and aberration, with an uncertainty of ±0.0003°
+// for years between -2000 and 6000. Dates are YYYY-MM-DD in the proleptic Gregorian calendar, so a calculation
+// covers the years 0 to 6000 of that range.
+type SPA struct {
+	// DeltaT is the difference between Terrestrial Time and Universal Time (TT - UT), in seconds.
+	// It is about 69 seconds in the 2020s.
+	DeltaT float64
+}
+
+// Sun returns the position of the sun at the Julian Day jd (UT) using the Solar Position Algorithm
+func (e SPA) Sun(jd float64) SunCoordinates {
+	jde := jd + e.DeltaT/86400
+	jce := (jde - 2451545) / 36525
+	jme := jce / 10
+
+	// Heliocentric longitude, latitude and radius vector of the Earth
+	l := normalizeDegrees(degrees(spaEarthValue(spaLTerms, jme)))
+	b := degrees(spaEarthValue(spaBTerms, jme))
+	r := spaEarthValue(spaRTerms, jme)
+
+	// Geocentric longitude and latitude of the sun
+	theta := normalizeDegrees(l + 180)
+	beta := -b
+
+	deltaPsi, deltaEpsilon := spaNutation(jce)
+	epsilon := spaMeanObliquity(jme)/3600 + deltaEpsilon
+
+	// Aberration correction and apparent sun longitude
+	deltaTau := -20.4898 / (3600 * r)
+	lambda := theta + deltaPsi + deltaTau
+
+	lambdaRad := radians(lambda)
+	epsilonRad := radians(epsilon)
+	betaRad := radians(beta)
+
+	alpha := normalizeDegrees(degrees(math.Atan2(
+		math.Sin(lambdaRad)*math.Cos(epsilonRad)-math.Tan(betaRad)*math.Sin(epsilonRad),
+		math.Cos(lambdaRad),
+	)))
+	delta := degrees(math.Asin(
+		math.Sin(betaRad)*math.Cos(epsilonRad) + math.Cos(betaRad)*math.Sin(epsilonRad)*math.Sin(lambdaRad),
+	))
+
+	// Apparent sidereal time at Greenwich
+	nu := greenwichApparentSiderealTime(jd, deltaPsi, epsilon)
+
+	return SunCoordinates{
+		EclipticLongitude: normalizeDegrees(lambda),
+		Obliquity:         epsilon,
+		Nutation:          deltaPsi,
+		RightAscension:    alpha,
+		Declination:       delta,
+		RadiusVector:      r,
+		EquationOfTime:    spaEquationOfTime(jd, nu, alpha),
+	}
+}
+
+// spaEarthValue sums the periodic terms of one of the Earth's heliocentric coordinates for jme Julian millennia
+func spaEarthValue(terms [][][3]float64, jme float64) float64 {
+	var value float64
+	for i, series := range terms {
+		var sum float64
+		for _, term := range series {
+			sum += term[0] * math.Cos(term[1]+term[2]*jme)
+		}
+		value += sum * math.Pow(jme, float64(i))
+	}
+	return value / 1e8
+}
+
+// spaNutation returns the nutation in longitude and in obliquity, in degrees, for jce Julian ephemeris centuries
+func spaNutation(jce float64) (deltaPsi, deltaEpsilon float64) {
+	jce2 := jce * jce
+	jce3 := jce2 * jce
+
+	arguments := [5]float64{
+		// Mean elongation of the moon from the sun
+		297.85036 + 445267.111480*jce - 0.0019142*jce2 + jce3/189474,
+		// Mean anomaly of the sun
+		357.52772 + 35999.050340*jce - 0.0001603*jce2 - jce3/300000,
+		// Mean anomaly of the moon
+		134.96298 + 477198.867398*jce + 0.0086972*jce2 + jce3/56250,
+		// Moon's argument of latitude
+		93.27191 + 483202.017538*jce - 0.0036825*jce2 + jce3/327270,
+		// Longitude of the ascending node of the moon's mean orbit
+		125.04452 - 1934.136261*jce + 0.0020708*jce2 + jce3/450000,
+	}
+
+	for _, term := range spaNutationTerms {
+		var argument float64
+		for j, x := range arguments {
+			argument += x * term[j]
+		}
+		argument = radians(argument)
+
+		deltaPsi += (term[5] + term[6]*jce) * math.Sin(argument)
+		deltaEpsilon += (term[7] + term[8]*jce) * math.Cos(argument)
+	}
+
+	return deltaPsi / 36000000, deltaEpsilon / 36000000
+}
+
+// spaMeanObliquity returns the mean obliquity of the ecliptic, in arc seconds, for jme Julian ephemeris millennia
+func spaMeanObliquity(jme float64) float64 {
+	u := jme / 10
+	coefficients := []float64{84381.448, -4680.93, -1.55, 1999.25, -51.38, -249.67, -39.05, 7.12, 27.87, 5.79, 2.45}
+
+	var epsilon0 float64
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		epsilon0 = epsilon0*u + coefficients[i]
+	}
+	return epsilon0
+}
+
+// spaEquationOfTime returns the equation of time, in minutes, as the difference between the apparent solar time,
+// given by the apparent sidereal time nu and the right ascension alpha, and the mean solar time at jd (UT)
+func spaEquationOfTime(jd, nu, alpha float64) float64 {
+	utMinutes := math.Mod(jd+0.5, 1) * 1440
+	eot := math.Mod(4*(nu-alpha)+720-utMinutes, 1440)
+
+	switch {
+	case eot > 720:
+		eot -= 1440
+	case eot < -720:
+		eot += 1440
+	}
+	return eot
+}
@@ -0,0 +1,99 @@
+package gosolar
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// DSTPolicy decides which UTC offset is used for local times that are ambiguous (they happen twice when clocks
+// are set back) or nonexistent (they are skipped when clocks are set forward) around DST transitions.
+type DSTPolicy int
+
+const (
+	// DSTEarlier resolves the local time to the earlier of the two candidate instants
+	DSTEarlier DSTPolicy = iota
+	// DSTLater resolves the local time to the later of the two candidate instants
+	DSTLater
+	// DSTError rejects ambiguous and nonexistent local times with ErrAmbiguousTime or ErrNonexistentTime
+	DSTError
+)
+
+var (
+	// ErrAmbiguousTime is returned by DSTError when the local time happens twice
+	ErrAmbiguousTime = errors.New("local time is ambiguous due to a DST transition")
+	// ErrNonexistentTime is returned by DSTError when the local time is skipped
+	ErrNonexistentTime = errors.New("local time does not exist due to a DST transition")
+)
+
+// TimeZoneOffsetAt returns the offset in seconds for a given TimeZone id at the local date (YYYY-MM-DD) and
+// fractional time of the day. Local times around DST transitions are resolved using policy.
+func TimeZoneOffsetAt(timeZoneId, date string, dayTime float64, policy DSTPolicy) (int, error) {
+	location, err := loadLocation(timeZoneId)
+	if err != nil {
+		return -1, err
+	}
+	return zoneOffset(location, date, dayTime, policy)
+}
+
+// zoneOffset returns the offset in seconds that location has at the given local date and time of the day
+func zoneOffset(location *time.Location, date string, dayTime float64, policy DSTPolicy) (int, error) {
+	if err := validateDate(date); err != nil {
+		return -1, err
+	}
+	day, _ := time.Parse("2006-01-02", date)
+	// wall holds the local clock reading as if it were UTC
+	wall := day.Add(time.Duration(math.Round(dayTime * 24 * float64(time.Hour))))
+
+	// Offsets in use a day before and after the wall time cover any transition affecting it
+	var candidates []int
+	for _, probe := range []time.Duration{-24 * time.Hour, 0, 24 * time.Hour} {
+		_, offset := wall.Add(probe).In(location).Zone()
+		if !containsOffset(candidates, offset) {
+			candidates = append(candidates, offset)
+		}
+	}
+
+	// An offset is valid when the instant it produces is actually observed with that offset
+	var valid []int
+	for _, offset := range candidates {
+		_, observed := wall.Add(-time.Duration(offset) * time.Second).In(location).Zone()
+		if observed == offset {
+			valid = append(valid, offset)
+		}
+	}
+
+	if len(valid) == 1 {
+		return valid[0], nil
+	}
+
+	// Either the wall time happens twice (two valid offsets) or never (none). The larger offset gives the
+	// earlier instant in both cases.
+	options := valid
+	policyErr := ErrAmbiguousTime
+	if len(valid) == 0 {
+		options = candidates
+		policyErr = ErrNonexistentTime
+	}
+	sort.Ints(options)
+
+	switch policy {
+	case DSTEarlier:
+		return options[len(options)-1], nil
+	case DSTLater:
+		return options[0], nil
+	default:
+		return -1, policyErr
+	}
+}
+
+// containsOffset reports whether offset is already in offsets
+func containsOffset(offsets []int, offset int) bool {
+	for _, o := range offsets {
+		if o == offset {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package gosolar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeZoneOffsetAt(t *testing.T) {
+	const newYork = "America/New_York"
+	oneThirty := 1.5 / 24
+	twoThirty := 2.5 / 24
+
+	tests := []struct {
+		name    string
+		date    string
+		dayTime float64
+		policy  DSTPolicy
+		want    int
+		wantErr error
+	}{
+		{"winter", "2023-01-01", 0.5, DSTError, -18000, nil},
+		{"summer", "2023-07-01", 0.5, DSTError, -14400, nil},
+		{"before spring forward", "2023-03-12", oneThirty, DSTError, -18000, nil},
+		{"after spring forward", "2023-03-12", 3.5 / 24, DSTError, -14400, nil},
+		{"nonexistent earlier", "2023-03-12", twoThirty, DSTEarlier, -14400, nil},
+		{"nonexistent later", "2023-03-12", twoThirty, DSTLater, -18000, nil},
+		{"nonexistent error", "2023-03-12", twoThirty, DSTError, 0, ErrNonexistentTime},
+		{"ambiguous earlier", "2023-11-05", oneThirty, DSTEarlier, -14400, nil},
+		{"ambiguous later", "2023-11-05", oneThirty, DSTLater, -18000, nil},
+		{"ambiguous error", "2023-11-05", oneThirty, DSTError, 0, ErrAmbiguousTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := TimeZoneOffsetAt(newYork, tt.date, tt.dayTime, tt.policy)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, offset)
+		})
+	}
+}
+
+func TestDSTPolicyOption(t *testing.T) {
+	_, err := Calculator(40.7128, -74.006, 1.5/24, "America/New_York", "2023-11-05", WithDSTPolicy(DSTError))
+	assert.ErrorIs(t, err, ErrAmbiguousTime)
+
+	calc, err := Calculator(40.7128, -74.006, 1.5/24, "America/New_York", "2023-11-05", WithDSTPolicy(DSTLater))
+	require.NoError(t, err)
+	assert.Equal(t, -5.0, calc.GetTimeZoneOffset())
+
+	require.NoError(t, calc.SetDSTPolicy(DSTEarlier))
+	assert.Equal(t, -4.0, calc.GetTimeZoneOffset())
+	assert.ErrorIs(t, calc.SetDSTPolicy(DSTError), ErrAmbiguousTime)
+	assert.Equal(t, DSTEarlier, calc.GetDSTPolicy())
+}
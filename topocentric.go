@@ -0,0 +1,77 @@
+package gosolar
+
+import "math"
+
+// earthRadius is the equatorial radius of the Earth, in meters
+const earthRadius = 6378140.0
+
+// TopocentricPosition holds the position of the sun as seen from the observer's location, which differs from the
+// geocentric position by the solar parallax
+type TopocentricPosition struct {
+	RightAscension float64 // in [0, 360) degrees
+	Declination    float64 // in degrees
+	HourAngle      float64 // in degrees, negative before solar noon
+	Zenith         float64 // geometric zenith angle, in degrees
+	Azimuth        float64 // in degrees clockwise from north
+}
+
+// Topocentric returns the topocentric position of the sun for the observer's latitude, longitude and elevation,
+// following the parallax correction of NREL's Solar Position Algorithm
+func (sc *SolarCalculation) Topocentric() TopocentricPosition {
+	sun := sc.sun()
+	latitude := radians(sc.latitude)
+	declination := radians(sun.Declination)
+	hourAngle := radians(sc.SunHourAngle())
+	height := sc.elevation + sc.observerHeight
+
+	// Equatorial horizontal parallax of the sun
+	xi := radians(8.794 / (3600 * sun.RadiusVector))
+
+	u := math.Atan(0.99664719 * math.Tan(latitude))
+	x := math.Cos(u) + height/earthRadius*math.Cos(latitude)
+	y := 0.99664719*math.Sin(u) + height/earthRadius*math.Sin(latitude)
+
+	denominator := math.Cos(declination) - x*math.Sin(xi)*math.Cos(hourAngle)
+	deltaAlpha := math.Atan2(-x*math.Sin(xi)*math.Sin(hourAngle), denominator)
+	topoDeclination := math.Atan2((math.Sin(declination)-y*math.Sin(xi))*math.Cos(deltaAlpha), denominator)
+	topoHourAngle := hourAngle - deltaAlpha
+
+	elevation := math.Asin(math.Sin(latitude)*math.Sin(topoDeclination) +
+		math.Cos(latitude)*math.Cos(topoDeclination)*math.Cos(topoHourAngle))
+	azimuth := math.Atan2(math.Sin(topoHourAngle),
+		math.Cos(topoHourAngle)*math.Sin(latitude)-math.Tan(topoDeclination)*math.Cos(latitude))
+
+	return TopocentricPosition{
+		RightAscension: normalizeDegrees(sun.RightAscension + degrees(deltaAlpha)),
+		Declination:    degrees(topoDeclination),
+		HourAngle:      degrees(topoHourAngle),
+		Zenith:         90 - degrees(elevation),
+		Azimuth:        normalizeDegrees(degrees(azimuth) + 180),
+	}
+}
+
+// TopocentricRightAscension returns the right ascension of the sun seen from the observer's location, in degrees
+func (sc *SolarCalculation) TopocentricRightAscension() float64 {
+	return sc.Topocentric().RightAscension
+}
+
+// TopocentricDeclination returns the declination of the sun seen from the observer's location, in degrees
+func (sc *SolarCalculation) TopocentricDeclination() float64 {
+	return sc.Topocentric().Declination
+}
+
+// TopocentricHourAngle returns the hour angle of the sun seen from the observer's location, in degrees
+func (sc *SolarCalculation) TopocentricHourAngle() float64 {
+	return sc.Topocentric().HourAngle
+}
+
+// TopocentricZenithAngle returns the zenith angle of the sun seen from the observer's location, in degrees
+func (sc *SolarCalculation) TopocentricZenithAngle() float64 {
+	return sc.Topocentric().Zenith
+}
+
+// TopocentricAzimuthAngle returns the azimuth of the sun seen from the observer's location, in degrees clockwise
+// from north
+func (sc *SolarCalculation) TopocentricAzimuthAngle() float64 {
+	return sc.Topocentric().Azimuth
+}
@@ -0,0 +1,38 @@
+package gosolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTopocentric checks the example given in the NREL report "Solar Position Algorithm for Solar Radiation
+// Applications" (Reda & Andreas, 2008)
+func TestTopocentric(t *testing.T) {
+	instant := time.Date(2003, time.October, 17, 12, 30, 30, 0, time.FixedZone("", -7*3600))
+	calc, err := CalculatorFromTime(39.742476, -105.1786, instant, WithEngine(SPA{DeltaT: 67}), WithElevation(1830.14))
+	require.NoError(t, err)
+
+	topo := calc.Topocentric()
+	assert.InDelta(t, 202.22704, topo.RightAscension, 1e-5)
+	assert.InDelta(t, -9.316179, topo.Declination, 1e-6)
+	assert.InDelta(t, 11.10629, topo.HourAngle, 3e-5)
+	assert.InDelta(t, 90-39.872046, topo.Zenith, 1e-5)
+	assert.InDelta(t, 194.340241, topo.Azimuth, 1e-5)
+
+	assert.Equal(t, topo.Zenith, calc.TopocentricZenithAngle())
+	assert.Equal(t, topo.Azimuth, calc.TopocentricAzimuthAngle())
+	assert.Equal(t, topo.Declination, calc.TopocentricDeclination())
+	assert.Equal(t, topo.RightAscension, calc.TopocentricRightAscension())
+	assert.Equal(t, topo.HourAngle, calc.TopocentricHourAngle())
+}
+
+func TestTopocentricParallax(t *testing.T) {
+	// The parallax lowers the sun by at most 8.8 arc seconds
+	topo := sc.Topocentric()
+	assert.Greater(t, topo.Zenith, sc.SolarZenithAngle())
+	assert.Less(t, topo.Zenith-sc.SolarZenithAngle(), 8.8/3600)
+	assert.InDelta(t, sc.SolarAzimuthAngle(), topo.Azimuth, 0.001)
+}
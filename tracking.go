@@ -0,0 +1,103 @@
+package gosolar
+
+import "math"
+
+// SingleAxisTracker describes a single-axis tracker, whose surface rotates around an axis to follow the sun
+type SingleAxisTracker struct {
+	// AxisTilt is the tilt of the rotation axis from horizontal, in degrees
+	AxisTilt float64
+	// AxisAzimuth is the direction of the rotation axis, in degrees clockwise from north, e.g. 0 or 180 for a
+	// north-south axis
+	AxisAzimuth float64
+	// MaxAngle limits the rotation to [-MaxAngle, MaxAngle] degrees. A non-positive value means 90.
+	MaxAngle float64
+	// GroundCoverageRatio is the width of the surface divided by the distance between rows. Backtracking, to keep
+	// rows from shading each other when the sun is low, is applied only when it is positive.
+	GroundCoverageRatio float64
+}
+
+// TrackerPosition holds the orientation of a tracker surface and the incidence angle of the sun's rays on it.
+// Angles are in degrees and azimuths clockwise from north.
+type TrackerPosition struct {
+	IdealRotation  float64 // rotation that points the surface at the sun, before backtracking and limits
+	Rotation       float64 // rotation of the surface, positive when clockwise looking along the axis azimuth
+	SurfaceTilt    float64 // tilt of the surface from horizontal
+	SurfaceAzimuth float64 // direction the surface faces
+	Incidence      float64 // incidence angle of the sun's rays on the surface
+}
+
+// Position returns the orientation of the tracker for a sun at the given zenith and azimuth angles, in degrees,
+// following Marion and Dobos (2013) and, for backtracking, Anderson and Mikofski (2020). The tracker is stowed flat,
+// with a rotation of 0, while the sun is below the horizon.
+func (t SingleAxisTracker) Position(zenith, azimuth float64) TrackerPosition {
+	maxAngle := t.MaxAngle
+	if maxAngle <= 0 {
+		maxAngle = 90
+	}
+
+	var ideal, rotation float64
+	if zenith <= 90 {
+		ideal = t.idealRotation(zenith, azimuth)
+		rotation = ideal
+
+		if t.GroundCoverageRatio > 0 {
+			// Rows shade each other when the projection of the surface is wider than the distance between them
+			temp := math.Abs(math.Cos(radians(ideal)) / t.GroundCoverageRatio)
+			if temp < 1 {
+				rotation = ideal - math.Copysign(degrees(math.Acos(temp)), ideal)
+			}
+		}
+		rotation = math.Max(-maxAngle, math.Min(maxAngle, rotation))
+	}
+
+	tilt, surfaceAzimuth := t.surfaceOrientation(rotation)
+
+	return TrackerPosition{
+		IdealRotation:  ideal,
+		Rotation:       rotation,
+		SurfaceTilt:    tilt,
+		SurfaceAzimuth: surfaceAzimuth,
+		Incidence:      Incidence(direction(zenith, azimuth), SurfaceNormal(tilt, surfaceAzimuth)),
+	}
+}
+
+// SingleAxisTracking returns the orientation of a single-axis tracker for the current SolarZenithAngle and
+// SolarAzimuthAngle. The incidence angle is given by IncidenceOnTiltedSurface.
+func (sc *SolarCalculation) SingleAxisTracking(tracker SingleAxisTracker) TrackerPosition {
+	position := tracker.Position(sc.SolarZenithAngle(), sc.SolarAzimuthAngle())
+	position.Incidence = sc.IncidenceOnTiltedSurface(position.SurfaceTilt, position.SurfaceAzimuth)
+
+	return position
+}
+
+// idealRotation returns the rotation, in degrees, that places the sun in the plane normal to the surface that
+// contains the axis
+func (t SingleAxisTracker) idealRotation(zenith, azimuth float64) float64 {
+	sun := direction(zenith, azimuth)
+	x, y, z := sun.East, sun.North, sun.Up
+
+	// Sun position in the tracker frame, whose y axis runs along the rotation axis
+	sinAxisAzimuth, cosAxisAzimuth := math.Sincos(radians(t.AxisAzimuth))
+	sinAxisTilt, cosAxisTilt := math.Sincos(radians(t.AxisTilt))
+
+	xp := x*cosAxisAzimuth - y*sinAxisAzimuth
+	zp := x*sinAxisTilt*sinAxisAzimuth + y*sinAxisTilt*cosAxisAzimuth + z*cosAxisTilt
+
+	return degrees(math.Atan2(xp, zp))
+}
+
+// surfaceOrientation returns the tilt and azimuth, in degrees, of the surface at the given rotation
+func (t SingleAxisTracker) surfaceOrientation(rotation float64) (tilt, azimuth float64) {
+	tilt = degrees(math.Acos(math.Cos(radians(rotation)) * math.Cos(radians(t.AxisTilt))))
+
+	sinTilt := math.Sin(radians(tilt))
+	if sinTilt == 0 {
+		return tilt, normalizeDegrees(t.AxisAzimuth + 90)
+	}
+
+	delta := degrees(math.Asin(math.Max(-1, math.Min(1, math.Sin(radians(rotation))/sinTilt))))
+	if math.Abs(rotation) >= 90 {
+		delta = math.Copysign(180, rotation) - delta
+	}
+	return tilt, normalizeDegrees(t.AxisAzimuth + delta)
+}
@@ -0,0 +1,80 @@
+package gosolar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingleAxisTrackerNoon(t *testing.T) {
+	tracker := SingleAxisTracker{GroundCoverageRatio: 2.0 / 7}
+	position := tracker.Position(10, 180)
+
+	assert.InDelta(t, 0, position.Rotation, 1e-9)
+	assert.InDelta(t, 0, position.SurfaceTilt, 1e-9)
+	assert.InDelta(t, 90, position.SurfaceAzimuth, 1e-9)
+	assert.InDelta(t, 10, position.Incidence, 1e-9)
+}
+
+func TestSingleAxisTrackerNorthSouthAxis(t *testing.T) {
+	// A tracker facing the morning sun points straight at it
+	tracker := SingleAxisTracker{AxisAzimuth: 180}
+	position := tracker.Position(60, 90)
+
+	assert.InDelta(t, -60, position.Rotation, 1e-9)
+	assert.InDelta(t, 60, position.SurfaceTilt, 1e-9)
+	assert.InDelta(t, 90, position.SurfaceAzimuth, 1e-9)
+	assert.InDelta(t, 0, position.Incidence, 1e-6)
+}
+
+func TestSingleAxisTrackerBacktracking(t *testing.T) {
+	// Reference values from pvlib's test suite
+	position := SingleAxisTracker{}.Position(80, 90)
+	assert.InDelta(t, 80, position.Rotation, 1e-9)
+	assert.InDelta(t, 0, position.Incidence, 1e-6)
+
+	position = SingleAxisTracker{GroundCoverageRatio: 2.0 / 7}.Position(80, 90)
+	assert.InDelta(t, 80, position.IdealRotation, 1e-9)
+	assert.InDelta(t, 27.42833, position.Rotation, 1e-5)
+	assert.InDelta(t, 27.42833, position.SurfaceTilt, 1e-5)
+	assert.InDelta(t, 90, position.SurfaceAzimuth, 1e-5)
+	assert.InDelta(t, 52.5716, position.Incidence, 1e-4)
+}
+
+func TestSingleAxisTrackerLimits(t *testing.T) {
+	tracker := SingleAxisTracker{AxisAzimuth: 180, MaxAngle: 45}
+	position := tracker.Position(80, 270)
+	assert.InDelta(t, 80, position.IdealRotation, 1e-9)
+	assert.InDelta(t, 45, position.Rotation, 1e-9)
+	assert.InDelta(t, 270, position.SurfaceAzimuth, 1e-9)
+	assert.InDelta(t, 35, position.Incidence, 1e-6)
+
+	// Stowed flat at night
+	night := tracker.Position(110, 0)
+	assert.Equal(t, 0.0, night.Rotation)
+	assert.InDelta(t, 0, night.SurfaceTilt, 1e-9)
+	assert.InDelta(t, 110, night.Incidence, 1e-9)
+}
+
+func TestSingleAxisTrackerTiltedAxis(t *testing.T) {
+	// At solar noon a tracker on a south-tilted axis stays in its rest position, facing south
+	tracker := SingleAxisTracker{AxisTilt: 20, AxisAzimuth: 180}
+	position := tracker.Position(30, 180)
+
+	assert.InDelta(t, 0, position.Rotation, 1e-9)
+	assert.InDelta(t, 20, position.SurfaceTilt, 1e-9)
+	assert.InDelta(t, 180, position.SurfaceAzimuth, 1e-9)
+	assert.InDelta(t, 10, position.Incidence, 1e-6)
+}
+
+func TestSingleAxisTracking(t *testing.T) {
+	tracker := SingleAxisTracker{AxisAzimuth: 180, MaxAngle: 60, GroundCoverageRatio: 0.4}
+	position := sc.SingleAxisTracking(tracker)
+	expected := tracker.Position(sc.SolarZenithAngle(), sc.SolarAzimuthAngle())
+
+	assert.Equal(t, expected.Rotation, position.Rotation)
+	assert.InDelta(t, expected.Incidence, position.Incidence, 1e-6)
+
+	// Tracking never does worse than a horizontal surface
+	assert.LessOrEqual(t, position.Incidence, sc.SolarZenithAngle())
+}
@@ -0,0 +1,149 @@
+package gosolar
+
+import "math"
+
+// DefaultAlbedo is the ground reflectance of grass, a common default for the ground-reflected irradiance
+const DefaultAlbedo = 0.25
+
+// SkyModel selects the model used to transpose the diffuse horizontal irradiance to a tilted surface
+type SkyModel int
+
+const (
+	// SkyIsotropic assumes the diffuse irradiance is uniform over the sky dome (Liu and Jordan, 1963)
+	SkyIsotropic SkyModel = iota
+	// SkyKlucher adds horizon and circumsolar brightening under clear skies (Klucher, 1979)
+	SkyKlucher
+	// SkyHayDavies splits the diffuse irradiance into isotropic and circumsolar parts (Hay and Davies, 1980)
+	SkyHayDavies
+	// SkyReindl adds horizon brightening to Hay and Davies' model (Reindl et al., 1990)
+	SkyReindl
+	// SkyPerez uses Perez et al.'s (1990) all-sites composite coefficients
+	SkyPerez
+)
+
+// PlaneOfArray holds the components of the irradiance received by a tilted surface, in W/m²
+type PlaneOfArray struct {
+	Beam          float64 // direct irradiance from the solar disk
+	SkyDiffuse    float64 // diffuse irradiance from the sky dome
+	GroundDiffuse float64 // irradiance reflected by the ground
+}
+
+// Global returns the total irradiance received by the surface, in W/m²
+func (p PlaneOfArray) Global() float64 {
+	return p.Beam + p.SkyDiffuse + p.GroundDiffuse
+}
+
+// perezCoefficients holds the F1 and F2 coefficients of the all-sites composite 1990 Perez model, for each of the
+// eight sky clearness bins
+var perezCoefficients = [8][2][3]float64{
+	{{-0.008, 0.588, -0.062}, {-0.060, 0.072, -0.022}},
+	{{0.130, 0.683, -0.151}, {-0.019, 0.066, -0.029}},
+	{{0.330, 0.487, -0.221}, {0.055, -0.064, -0.026}},
+	{{0.568, 0.187, -0.295}, {0.109, -0.152, -0.014}},
+	{{0.873, -0.392, -0.362}, {0.226, -0.462, 0.001}},
+	{{1.132, -1.237, -0.412}, {0.288, -0.823, 0.056}},
+	{{1.060, -1.600, -0.359}, {0.264, -1.127, 0.131}},
+	{{0.678, -0.327, -0.250}, {0.156, -1.377, 0.251}},
+}
+
+// perezClearnessBins holds the upper limits of the first seven sky clearness bins of the Perez model
+var perezClearnessBins = [7]float64{1.065, 1.23, 1.5, 1.95, 2.8, 4.5, 6.2}
+
+// Transpose returns the irradiance received by a surface tilted by surfaceAngle degrees from horizontal, given the
+// incidence angle of the sun's rays on the surface and the zenith angle, in degrees, the horizontal irradiance
+// components, the extraterrestrial normal irradiance in W/m², the relative air mass and the ground albedo. Only the
+// anisotropic sky models use the extraterrestrial irradiance, and only SkyPerez the air mass. SkyPerez falls back
+// to SkyIsotropic when the sun is below the horizon.
+func Transpose(surfaceAngle, incidence, zenith float64, irradiance Irradiance, dniExtra, airMass, albedo float64, model SkyModel) PlaneOfArray {
+	cosIncidence := math.Max(math.Cos(radians(incidence)), 0)
+	cosTilt := math.Cos(radians(surfaceAngle))
+
+	return PlaneOfArray{
+		Beam:          math.Max(irradiance.DNI*cosIncidence, 0),
+		SkyDiffuse:    skyDiffuse(surfaceAngle, cosIncidence, zenith, irradiance, dniExtra, airMass, model),
+		GroundDiffuse: irradiance.GHI * albedo * (1 - cosTilt) / 2,
+	}
+}
+
+// PlaneOfArray returns the irradiance received by a surface with the given tilt and azimuth, in degrees as in
+// IncidenceOnTiltedSurface, from the horizontal irradiance components, e.g. measured, given by a clear-sky model or
+// by Decompose, and the ground albedo, e.g. DefaultAlbedo
+func (sc *SolarCalculation) PlaneOfArray(surfaceAngle, surfaceAzimuth float64, irradiance Irradiance, albedo float64, model SkyModel) PlaneOfArray {
+	zenith := sc.SolarZenithAngle()
+	incidence := sc.IncidenceOnTiltedSurface(surfaceAngle, surfaceAzimuth)
+
+	return Transpose(surfaceAngle, incidence, zenith, irradiance, sc.ExtraterrestrialIrradiance(),
+		RelativeAirMass(zenith, AirMassKastenYoung), albedo, model)
+}
+
+// skyDiffuse returns the diffuse irradiance from the sky dome received by a tilted surface, in W/m²
+func skyDiffuse(surfaceAngle, cosIncidence, zenith float64, irradiance Irradiance, dniExtra, airMass float64, model SkyModel) float64 {
+	dhi, dni, ghi := irradiance.DHI, irradiance.DNI, irradiance.GHI
+	cosZenith := math.Cos(radians(zenith))
+	isotropic := (1 + math.Cos(radians(surfaceAngle))) / 2
+	sinHalfTilt3 := math.Pow(math.Sin(radians(surfaceAngle/2)), 3)
+
+	// Ratio of the beam irradiance on the surface to the one on the horizontal, limited near the horizon
+	rb := cosIncidence / math.Max(cosZenith, 0.01745)
+	// Anisotropy index, the share of the diffuse irradiance coming from the circumsolar region
+	ai := dni / dniExtra
+
+	switch model {
+	case SkyKlucher:
+		var f float64
+		if ghi != 0 {
+			f = 1 - (dhi/ghi)*(dhi/ghi)
+		}
+		sinZenith3 := math.Pow(math.Sin(radians(zenith)), 3)
+		return dhi * isotropic * (1 + f*sinHalfTilt3) * (1 + f*cosIncidence*cosIncidence*sinZenith3)
+
+	case SkyHayDavies:
+		return math.Max(dhi*(1-ai)*isotropic, 0) + math.Max(dhi*ai*rb, 0)
+
+	case SkyReindl:
+		var hbToGHI float64
+		if ghi != 0 {
+			hbToGHI = math.Max(dni*cosZenith, 0) / ghi
+		}
+		return math.Max(dhi*(ai*rb+(1-ai)*isotropic*(1+math.Sqrt(hbToGHI)*sinHalfTilt3)), 0)
+
+	case SkyPerez:
+		if dhi > 0 && !math.IsNaN(airMass) {
+			return perezSkyDiffuse(surfaceAngle, cosIncidence, zenith, dhi, dni, dniExtra, airMass)
+		}
+	}
+
+	return dhi * isotropic
+}
+
+// perezSkyDiffuse returns the diffuse irradiance from the sky dome given by the Perez model, in W/m²
+func perezSkyDiffuse(surfaceAngle, cosIncidence, zenith, dhi, dni, dniExtra, airMass float64) float64 {
+	const kappa = 1.041
+	z := radians(zenith)
+
+	// Sky brightness and clearness
+	delta := dhi * airMass / dniExtra
+	epsilon := ((dhi+dni)/dhi + kappa*z*z*z) / (1 + kappa*z*z*z)
+
+	bin := len(perezClearnessBins)
+	for i, limit := range perezClearnessBins {
+		if epsilon < limit {
+			bin = i
+			break
+		}
+	}
+
+	// Circumsolar and horizon brightening coefficients
+	f1c, f2c := perezCoefficients[bin][0], perezCoefficients[bin][1]
+	f1 := math.Max(f1c[0]+f1c[1]*delta+f1c[2]*z, 0)
+	f2 := f2c[0] + f2c[1]*delta + f2c[2]*z
+
+	a := cosIncidence
+	b := math.Max(math.Cos(z), math.Cos(radians(85)))
+
+	term1 := (1 - f1) * (1 + math.Cos(radians(surfaceAngle))) / 2
+	term2 := f1 * a / b
+	term3 := f2 * math.Sin(radians(surfaceAngle))
+
+	return math.Max(dhi*(term1+term2+term3), 0)
+}
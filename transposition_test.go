@@ -0,0 +1,115 @@
+package gosolar
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var skyModels = []SkyModel{SkyIsotropic, SkyKlucher, SkyHayDavies, SkyReindl, SkyPerez}
+
+func TestTransposeIsotropic(t *testing.T) {
+	irradiance := Irradiance{GHI: 800, DNI: 700, DHI: 150}
+	poa := Transpose(30, 20, 40, irradiance, 1400, RelativeAirMass(40, AirMassKastenYoung), 0.2, SkyIsotropic)
+
+	assert.InDelta(t, 700*math.Cos(radians(20)), poa.Beam, 1e-9)
+	assert.InDelta(t, 150*(1+math.Cos(radians(30)))/2, poa.SkyDiffuse, 1e-9)
+	assert.InDelta(t, 800*0.2*(1-math.Cos(radians(30)))/2, poa.GroundDiffuse, 1e-9)
+	assert.InDelta(t, poa.Beam+poa.SkyDiffuse+poa.GroundDiffuse, poa.Global(), 1e-9)
+}
+
+func TestTransposeHorizontalSurface(t *testing.T) {
+	irradiance := Irradiance{GHI: 800, DNI: 700, DHI: 150}
+	airMass := RelativeAirMass(40, AirMassKastenYoung)
+
+	// A horizontal surface sees the whole sky dome and no ground
+	for _, model := range []SkyModel{SkyIsotropic, SkyHayDavies, SkyReindl, SkyPerez} {
+		poa := Transpose(0, 40, 40, irradiance, 1400, airMass, DefaultAlbedo, model)
+		assert.InDelta(t, 150, poa.SkyDiffuse, 1e-9)
+		assert.Equal(t, 0.0, poa.GroundDiffuse)
+	}
+
+	// Klucher's circumsolar brightening also applies to a horizontal surface
+	klucher := Transpose(0, 40, 40, irradiance, 1400, airMass, DefaultAlbedo, SkyKlucher)
+	assert.Greater(t, klucher.SkyDiffuse, 150.0)
+}
+
+func TestTransposeAnisotropicModels(t *testing.T) {
+	irradiance := Irradiance{GHI: 800, DNI: 700, DHI: 150}
+	airMass := RelativeAirMass(40, AirMassKastenYoung)
+	isotropic := Transpose(30, 20, 40, irradiance, 1400, airMass, DefaultAlbedo, SkyIsotropic)
+
+	// Under a clear sky, a surface facing the sun gets more diffuse irradiance than the isotropic sky gives
+	for _, model := range skyModels[1:] {
+		poa := Transpose(30, 20, 40, irradiance, 1400, airMass, DefaultAlbedo, model)
+		assert.Greater(t, poa.SkyDiffuse, isotropic.SkyDiffuse, "model %d", model)
+		assert.Less(t, poa.SkyDiffuse, 2*isotropic.SkyDiffuse, "model %d", model)
+		assert.Equal(t, isotropic.Beam, poa.Beam)
+		assert.Equal(t, isotropic.GroundDiffuse, poa.GroundDiffuse)
+	}
+
+	// Hay-Davies splits the diffuse irradiance between the circumsolar region and an isotropic sky
+	ai := 700.0 / 1400
+	rb := math.Cos(radians(20)) / math.Cos(radians(40))
+	hayDavies := Transpose(30, 20, 40, irradiance, 1400, airMass, DefaultAlbedo, SkyHayDavies)
+	assert.InDelta(t, 150*(ai*rb+(1-ai)*(1+math.Cos(radians(30)))/2), hayDavies.SkyDiffuse, 1e-9)
+}
+
+func TestTransposeReferenceValues(t *testing.T) {
+	// Expected values were computed outside this package from the published model equations, with pvlib's
+	// allsitescomposite1990 coefficients for Perez, for a surface tilted 30° with the sun 40° from the zenith and 20°
+	// from the surface normal
+	irradiance := Irradiance{GHI: 800, DNI: 700, DHI: 150}
+	airMass := RelativeAirMass(40, AirMassKastenYoung)
+
+	sky := func(irradiance Irradiance, model SkyModel) float64 {
+		return Transpose(30, 20, 40, irradiance, 1400, airMass, DefaultAlbedo, model).SkyDiffuse
+	}
+	assert.InDelta(t, 174.489980029, sky(irradiance, SkyKlucher), 1e-6)
+	assert.InDelta(t, 161.977072410, sky(irradiance, SkyHayDavies), 1e-6)
+	assert.InDelta(t, 162.970345160, sky(irradiance, SkyReindl), 1e-6)
+
+	// Perez, in three sky clearness bins
+	assert.InDelta(t, 185.439111700, sky(irradiance, SkyPerez), 1e-6)
+	assert.InDelta(t, 310.219491450, sky(Irradiance{GHI: 415, DNI: 150, DHI: 300}, SkyPerez), 1e-6)
+	assert.InDelta(t, 95.040972490, sky(Irradiance{GHI: 769, DNI: 900, DHI: 80}, SkyPerez), 1e-6)
+}
+
+func TestTransposePerezOvercast(t *testing.T) {
+	// Without beam irradiance the clearness is 1 and the Perez model uses its first bin
+	irradiance := Irradiance{GHI: 200, DHI: 200}
+	poa := Transpose(30, 20, 40, irradiance, 1400, RelativeAirMass(40, AirMassKastenYoung), DefaultAlbedo, SkyPerez)
+
+	assert.Equal(t, 0.0, poa.Beam)
+	assert.InDelta(t, 200*(1+math.Cos(radians(30)))/2, poa.SkyDiffuse, 20)
+}
+
+func TestTransposeSunBehindSurface(t *testing.T) {
+	irradiance := Irradiance{GHI: 300, DNI: 400, DHI: 100}
+	airMass := RelativeAirMass(60, AirMassKastenYoung)
+
+	for _, model := range skyModels {
+		poa := Transpose(60, 110, 60, irradiance, 1400, airMass, DefaultAlbedo, model)
+		assert.Equal(t, 0.0, poa.Beam)
+		assert.GreaterOrEqual(t, poa.SkyDiffuse, 0.0)
+	}
+
+	// Below the horizon the Perez model falls back to the isotropic sky
+	twilight := Irradiance{GHI: 10, DHI: 10}
+	assert.Equal(t,
+		Transpose(30, 100, 95, twilight, 1400, math.NaN(), DefaultAlbedo, SkyIsotropic),
+		Transpose(30, 100, 95, twilight, 1400, math.NaN(), DefaultAlbedo, SkyPerez))
+}
+
+func TestPlaneOfArray(t *testing.T) {
+	irradiance := sc.ClearSkyIneichen(3)
+
+	for _, model := range skyModels {
+		poa := sc.PlaneOfArray(35, 180, irradiance, DefaultAlbedo, model)
+		assert.InDelta(t, irradiance.DNI*math.Cos(radians(sc.IncidenceOnTiltedSurface(35, 180))), poa.Beam, 1e-9)
+
+		// In January at 23°N, a south-facing panel tilted 35° receives more than the horizontal
+		assert.Greater(t, poa.Global(), irradiance.GHI, "model %d", model)
+	}
+}
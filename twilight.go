@@ -0,0 +1,123 @@
+package gosolar
+
+import (
+	"errors"
+	"math"
+)
+
+// Zenith angles, in degrees, of the sun at the events computed by SunriseAndSunset and TwilightTimes
+const (
+	// SunriseZenith accounts for the refraction at the horizon, at sea level, and the radius of the solar disk
+	SunriseZenith              = 90.833
+	CivilTwilightZenith        = 96.0
+	NauticalTwilightZenith     = 102.0
+	AstronomicalTwilightZenith = 108.0
+)
+
+// horizonRefraction is the refraction at the horizon, in degrees, included in SunriseZenith
+const horizonRefraction = 34.0 / 60
+
+// SunMotion tells whether an event happens while the sun is rising, before solar noon, or setting, after it
+type SunMotion int
+
+const (
+	Rising SunMotion = iota
+	Setting
+)
+
+// DayState tells whether the sun crosses a zenith angle during the day
+type DayState int
+
+const (
+	// NormalDay means the sun crosses the zenith angle once while rising and once while setting
+	NormalDay DayState = iota
+	// AlwaysUp means the sun stays above the zenith angle all day, e.g. polar day for sunrise and sunset
+	AlwaysUp
+	// AlwaysDown means the sun stays below the zenith angle all day, e.g. polar night for sunrise and sunset
+	AlwaysDown
+)
+
+var (
+	// ErrSunAlwaysUp is returned when the sun stays above the requested zenith angle all day
+	ErrSunAlwaysUp = errors.New("the sun stays above the zenith angle all day")
+	// ErrSunAlwaysDown is returned when the sun stays below the requested zenith angle all day
+	ErrSunAlwaysDown = errors.New("the sun stays below the zenith angle all day")
+)
+
+func (s DayState) String() string {
+	switch s {
+	case AlwaysUp:
+		return "always up"
+	case AlwaysDown:
+		return "always down"
+	default:
+		return "normal"
+	}
+}
+
+// err returns the error reported for events that do not happen in this state
+func (s DayState) err() error {
+	switch s {
+	case AlwaysUp:
+		return ErrSunAlwaysUp
+	case AlwaysDown:
+		return ErrSunAlwaysDown
+	default:
+		return nil
+	}
+}
+
+// Twilight identifies one of the three twilight definitions, each bounded by a zenith angle of the sun
+type Twilight int
+
+const (
+	// CivilTwilight ends when the sun is 6° below the horizon
+	CivilTwilight Twilight = iota
+	// NauticalTwilight ends when the sun is 12° below the horizon
+	NauticalTwilight
+	// AstronomicalTwilight ends when the sun is 18° below the horizon
+	AstronomicalTwilight
+)
+
+// Zenith returns the zenith angle of the sun, in degrees, that bounds the twilight
+func (t Twilight) Zenith() float64 {
+	switch t {
+	case NauticalTwilight:
+		return NauticalTwilightZenith
+	case AstronomicalTwilight:
+		return AstronomicalTwilightZenith
+	default:
+		return CivilTwilightZenith
+	}
+}
+
+// TwilightTimes returns the start of the morning twilight (dawn) and the end of the evening twilight (dusk), in
+// hours, using the same time scale as SunriseAndSunset. The twilight zenith angles are defined against the
+// astronomical horizon, so unlike sunrise and sunset they do not depend on the horizon dip or the site elevation.
+// ErrSunAlwaysUp is returned when the twilight lasts all night and ErrSunAlwaysDown when it does not happen at all.
+func (sc *SolarCalculation) TwilightTimes(twilight Twilight) (dawn, dusk float64, err error) {
+	zenith := twilight.Zenith()
+	if dawn, err = sc.TimeAtZenith(zenith, Rising); err != nil {
+		return dawn, dawn, err
+	}
+	dusk, err = sc.TimeAtZenith(zenith, Setting)
+	return dawn, dusk, err
+}
+
+// HorizonDip returns how far below the astronomical horizon, in degrees, the visible horizon lies for an observer
+// raised above the surrounding terrain. Only the observer height counts: the site elevation lifts the horizon as much
+// as the observer, so a plateau has no dip of its own. The dip includes the terrestrial refraction.
+func (sc *SolarCalculation) HorizonDip() float64 {
+	if sc.observerHeight <= 0 {
+		return 0
+	}
+	// 1.76 arc minutes per square root of meter
+	return 1.76 / 60 * math.Sqrt(sc.observerHeight)
+}
+
+// sunriseZenith returns the zenith angle of the sun at sunrise and sunset. The refraction at the horizon is scaled
+// for the site pressure, so it weakens with the elevation, and the horizon dip is added for the observer height.
+func (sc *SolarCalculation) sunriseZenith() float64 {
+	refraction := horizonRefraction * (sc.sitePressure()/PressureFromElevation(0) - 1)
+	return SunriseZenith + refraction + sc.HorizonDip()
+}
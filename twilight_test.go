@@ -0,0 +1,136 @@
+package gosolar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeAtZenith(t *testing.T) {
+	sunrise, sunset := sc.SunriseAndSunset()
+
+	rise, err := sc.TimeAtZenith(SunriseZenith, Rising)
+	require.NoError(t, err)
+	assert.Equal(t, sunrise, rise)
+
+	set, err := sc.TimeAtZenith(SunriseZenith, Setting)
+	require.NoError(t, err)
+	assert.Equal(t, sunset, set)
+
+	nearNoon, err := sc.TimeAtZenith(46.5, Rising)
+	require.NoError(t, err)
+	assert.InDelta(t, sc.SolarNoon()*24, nearNoon, 0.5)
+
+	_, err = sc.TimeAtZenith(30, Rising)
+	assert.ErrorIs(t, err, ErrSunAlwaysDown)
+}
+
+func TestTwilightTimes(t *testing.T) {
+	sunrise, sunset := sc.SunriseAndSunset()
+	previousDawn, previousDusk := sunrise, sunset
+
+	for _, twilight := range []Twilight{CivilTwilight, NauticalTwilight, AstronomicalTwilight} {
+		dawn, dusk, err := sc.TwilightTimes(twilight)
+		require.NoError(t, err)
+		assert.Less(t, dawn, previousDawn)
+		assert.Greater(t, dusk, previousDusk)
+		previousDawn, previousDusk = dawn, dusk
+	}
+
+	// Havana's civil twilight lasts about 24 minutes in January
+	dawn, dusk, err := sc.TwilightTimes(CivilTwilight)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.4, sunrise-dawn, 0.05)
+	assert.InDelta(t, 0.4, dusk-sunset, 0.05)
+}
+
+func TestPolarDayAndNight(t *testing.T) {
+	// Tromsø, Norway
+	winter, err := Calculator(69.6492, 18.9553, 0.5, "Europe/Oslo", "2023-12-21")
+	require.NoError(t, err)
+	assert.Equal(t, AlwaysDown, winter.DayState())
+	assert.Equal(t, 0.0, winter.DayLength())
+	sunrise, sunset := winter.SunriseAndSunset()
+	assert.Equal(t, sunrise, sunset)
+
+	_, err = winter.TimeAtZenith(SunriseZenith, Rising)
+	assert.ErrorIs(t, err, ErrSunAlwaysDown)
+
+	// Civil twilight still happens around noon
+	assert.Equal(t, NormalDay, winter.DayStateAtZenith(CivilTwilightZenith))
+	_, _, err = winter.TwilightTimes(CivilTwilight)
+	assert.NoError(t, err)
+
+	summer, err := Calculator(69.6492, 18.9553, 0.5, "Europe/Oslo", "2023-06-21")
+	require.NoError(t, err)
+	assert.Equal(t, AlwaysUp, summer.DayState())
+	assert.Equal(t, 24.0, summer.DayLength())
+	assert.Equal(t, "always up", summer.DayState().String())
+
+	_, _, err = summer.TwilightTimes(AstronomicalTwilight)
+	assert.ErrorIs(t, err, ErrSunAlwaysUp)
+}
+
+func TestHorizonDip(t *testing.T) {
+	assert.Equal(t, 0.0, sc.HorizonDip())
+
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01",
+		WithElevation(80), WithObserverHeight(100))
+	require.NoError(t, err)
+	assert.InDelta(t, 1.76/6, calc.HorizonDip(), 1e-12)
+
+	sunrise, sunset := sc.SunriseAndSunset()
+	elevatedSunrise, elevatedSunset := calc.SunriseAndSunset()
+	assert.Less(t, elevatedSunrise, sunrise)
+	assert.Greater(t, elevatedSunset, sunset)
+	assert.Greater(t, calc.DayLength(), sc.DayLength())
+
+	// Twilight is defined against the astronomical horizon
+	dawn, _, err := sc.TwilightTimes(CivilTwilight)
+	require.NoError(t, err)
+	elevatedDawn, _, err := calc.TwilightTimes(CivilTwilight)
+	require.NoError(t, err)
+	assert.Equal(t, dawn, elevatedDawn)
+
+	require.NoError(t, calc.SetElevation(-430))
+	require.NoError(t, calc.SetObserverHeight(0))
+	assert.Equal(t, 0.0, calc.HorizonDip())
+	assert.Equal(t, -430.0, calc.GetElevation())
+}
+
+func TestElevationReducesHorizonRefraction(t *testing.T) {
+	// The horizon of a high plateau is as high as the observer standing on it, but the thinner air refracts less
+	calc, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01", WithElevation(2000))
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, calc.HorizonDip())
+
+	refraction := horizonRefraction * PressureFromElevation(2000) / SeaLevelPressure
+	assert.InDelta(t, SunriseZenith-horizonRefraction+refraction, calc.sunriseZenith(), 1e-6)
+
+	sunrise, sunset := sc.SunriseAndSunset()
+	plateauSunrise, plateauSunset := calc.SunriseAndSunset()
+	assert.Greater(t, plateauSunrise, sunrise)
+	assert.Less(t, plateauSunset, sunset)
+
+	// A measured pressure replaces the one derived from the elevation
+	require.NoError(t, calc.SetAtmosphere(PressureFromElevation(0), StandardTemperature))
+	assert.InDelta(t, SunriseZenith, calc.sunriseZenith(), 1e-12)
+
+	dawn, dusk, err := sc.TwilightTimes(NauticalTwilight)
+	require.NoError(t, err)
+	plateauDawn, plateauDusk, err := calc.TwilightTimes(NauticalTwilight)
+	require.NoError(t, err)
+	assert.Equal(t, dawn, plateauDawn)
+	assert.Equal(t, dusk, plateauDusk)
+}
+
+func TestElevationValidation(t *testing.T) {
+	_, err := Calculator(23.0975036, -82.4206579, 0.5, "America/New_York", "2023-01-01", WithElevation(9500))
+	assert.ErrorIs(t, err, ErrElevationRange)
+
+	assert.ErrorIs(t, sc.SetElevation(-600), ErrElevationRange)
+	assert.ErrorIs(t, sc.SetObserverHeight(-1), ErrObserverHeightRange)
+	assert.Equal(t, 0.0, sc.GetElevation())
+	assert.Equal(t, 0.0, sc.GetObserverHeight())
+}
@@ -0,0 +1,84 @@
+package gosolar
+
+import "math"
+
+// Vector is a direction, or a position in meters, in local East-North-Up coordinates
+type Vector struct {
+	East  float64
+	North float64
+	Up    float64
+}
+
+// Add returns the sum of two vectors
+func (v Vector) Add(w Vector) Vector {
+	return Vector{East: v.East + w.East, North: v.North + w.North, Up: v.Up + w.Up}
+}
+
+// Sub returns the difference of two vectors
+func (v Vector) Sub(w Vector) Vector {
+	return Vector{East: v.East - w.East, North: v.North - w.North, Up: v.Up - w.Up}
+}
+
+// Scale returns the vector multiplied by k
+func (v Vector) Scale(k float64) Vector {
+	return Vector{East: v.East * k, North: v.North * k, Up: v.Up * k}
+}
+
+// Dot returns the dot product of two vectors
+func (v Vector) Dot(w Vector) float64 {
+	return v.East*w.East + v.North*w.North + v.Up*w.Up
+}
+
+// Length returns the Euclidean length of the vector
+func (v Vector) Length() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Unit returns the vector scaled to a length of 1. The zero vector is returned unchanged.
+func (v Vector) Unit() Vector {
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+	return v.Scale(1 / length)
+}
+
+// direction returns the unit vector pointing to the given zenith and azimuth angles, in degrees
+func direction(zenith, azimuth float64) Vector {
+	sinZenith, cosZenith := math.Sincos(radians(zenith))
+	sinAzimuth, cosAzimuth := math.Sincos(radians(azimuth))
+
+	return Vector{East: sinZenith * sinAzimuth, North: sinZenith * cosAzimuth, Up: cosZenith}
+}
+
+// ZenithAzimuth returns the zenith angle and the azimuth, in degrees clockwise from north, of the direction of v
+func (v Vector) ZenithAzimuth() (zenith, azimuth float64) {
+	v = v.Unit()
+	zenith = degrees(math.Acos(math.Max(-1, math.Min(1, v.Up))))
+	azimuth = normalizeDegrees(degrees(math.Atan2(v.East, v.North)))
+	return zenith, azimuth
+}
+
+// SurfaceNormal returns the unit normal of a surface with the given tilt from horizontal and azimuth, in degrees
+// clockwise from north, as in IncidenceOnTiltedSurface
+func SurfaceNormal(tilt, azimuth float64) Vector {
+	return direction(tilt, azimuth)
+}
+
+// Incidence returns the angle, in degrees, between the direction of the sun and the normal of a surface, both given
+// as unit vectors. It is above 90 when the sun is behind the surface.
+func Incidence(sun, normal Vector) float64 {
+	return degrees(math.Acos(math.Max(-1, math.Min(1, sun.Dot(normal)))))
+}
+
+// SunVector returns the unit vector pointing from the site to the sun, in East-North-Up coordinates, for the
+// current SolarZenithAngle and SolarAzimuthAngle
+func (sc *SolarCalculation) SunVector() Vector {
+	return direction(sc.SolarZenithAngle(), sc.SolarAzimuthAngle())
+}
+
+// IncidenceOnSurface returns the incidence angle of the sun's rays, in degrees, on a surface with the given unit
+// normal, e.g. one from SurfaceNormal or from a 3D model. It matches IncidenceOnTiltedSurface for the same surface.
+func (sc *SolarCalculation) IncidenceOnSurface(normal Vector) float64 {
+	return Incidence(sc.SunVector(), normal)
+}
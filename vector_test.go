@@ -0,0 +1,55 @@
+package gosolar
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVector(t *testing.T) {
+	v := Vector{East: 3, North: 4}
+	assert.Equal(t, 5.0, v.Length())
+	assert.InDelta(t, 0.6, v.Unit().East, 1e-12)
+	assert.InDelta(t, 0.8, v.Unit().North, 1e-12)
+	assert.Equal(t, 25.0, v.Dot(v))
+	assert.Equal(t, Vector{East: 4, North: 4, Up: 1}, v.Add(Vector{East: 1, Up: 1}))
+	assert.Equal(t, Vector{East: 2, North: 4, Up: -1}, v.Sub(Vector{East: 1, Up: 1}))
+	assert.Equal(t, Vector{}, Vector{}.Unit())
+
+	zenith, azimuth := direction(60, 135).ZenithAzimuth()
+	assert.InDelta(t, 60, zenith, 1e-9)
+	assert.InDelta(t, 135, azimuth, 1e-9)
+}
+
+func TestSurfaceNormal(t *testing.T) {
+	up := SurfaceNormal(0, 123)
+	assert.InDelta(t, 0, up.East, 1e-12)
+	assert.InDelta(t, 0, up.North, 1e-12)
+	assert.InDelta(t, 1, up.Up, 1e-12)
+
+	east := SurfaceNormal(90, 90)
+	assert.InDelta(t, 1, east.East, 1e-12)
+	assert.InDelta(t, 0, east.North, 1e-12)
+	assert.InDelta(t, 0, east.Up, 1e-12)
+
+	assert.InDelta(t, 90, Incidence(up, east), 1e-9)
+	assert.InDelta(t, 180, Incidence(up, up.Scale(-1)), 1e-9)
+}
+
+func TestSunVector(t *testing.T) {
+	sun := sc.SunVector()
+	assert.InDelta(t, 1, sun.Length(), 1e-12)
+	assert.InDelta(t, sc.SolarIncidenceAngle(), degrees(math.Asin(sun.Up)), 1e-9)
+
+	zenith, azimuth := sun.ZenithAzimuth()
+	assert.InDelta(t, sc.SolarZenithAngle(), zenith, 1e-9)
+	assert.InDelta(t, sc.SolarAzimuthAngle(), azimuth, 1e-9)
+}
+
+func TestIncidenceOnSurface(t *testing.T) {
+	for _, surface := range [][2]float64{{0, 0}, {35, 180}, {45, 190}, {90, 90}, {60, 300}} {
+		normal := SurfaceNormal(surface[0], surface[1])
+		assert.InDelta(t, sc.IncidenceOnTiltedSurface(surface[0], surface[1]), sc.IncidenceOnSurface(normal), 1e-6, "surface %v", surface)
+	}
+}